@@ -0,0 +1,5 @@
+data "opentoolchain_integration_artifactory" "repo" {
+  toolchain_id   = opentoolchain_toolchain.tc.guid
+  integration_id = opentoolchain_integration_artifactory.repo.integration_id
+  env_id         = "ibm:yp:us-east"
+}
@@ -3,3 +3,10 @@ data "opentoolchain_integration_github" "gt" {
   integration_id = opentoolchain_integration_github.gt.integration_id
   env_id       = "ibm:yp:us-east"
 }
+
+# find integration by repository URL instead
+data "opentoolchain_integration_github" "app" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  repo_url     = "https://github.com/org/app"
+  env_id       = "ibm:yp:us-east"
+}
@@ -3,3 +3,10 @@ data "opentoolchain_integration_keyprotect" "kp" {
   integration_id = opentoolchain_integration_keyprotect.kp.integration_id
   env_id       = "ibm:yp:us-east"
 }
+
+# find integration by name instead
+data "opentoolchain_integration_keyprotect" "secrets" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  name         = "secrets"
+  env_id       = "ibm:yp:us-east"
+}
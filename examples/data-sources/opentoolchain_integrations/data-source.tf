@@ -0,0 +1,16 @@
+data "opentoolchain_integrations" "github" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  service_id   = "githubconsolidated"
+
+  parameters = {
+    repo_url = "https://github.com/org/repo"
+  }
+}
+
+data "opentoolchain_integrations" "kp" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  service_id   = "keyprotect"
+  name         = "secrets"
+}
@@ -0,0 +1,10 @@
+data "opentoolchain_tekton_pipelines" "ci" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-south"
+  name         = "ci-pipeline"
+}
+
+data "opentoolchain_tekton_pipeline_config" "ci" {
+  guid   = data.opentoolchain_tekton_pipelines.ci.pipelines[0].pipeline_id
+  env_id = "ibm:yp:us-south"
+}
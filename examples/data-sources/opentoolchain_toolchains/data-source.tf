@@ -0,0 +1,6 @@
+data "opentoolchain_toolchains" "team" {
+  env_id            = "ibm:yp:us-south"
+  resource_group_id = "6b0eb15c8fab4bc89fd2ef8d9e0dd6a4"
+  name_regex        = "^team-"
+  tags              = ["env:prod"]
+}
@@ -0,0 +1 @@
+terraform import opentoolchain_integration_app_configuration.flags <integration_id>/<toolchain_id>/<env_id>
@@ -0,0 +1,8 @@
+resource "opentoolchain_integration_app_configuration" "flags" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = "ibm:yp:us-east"
+  name            = "feature-flags"
+  instance_name   = "app-config"
+  instance_region = "ibm:yp:us-east"
+  resource_group  = "default"
+}
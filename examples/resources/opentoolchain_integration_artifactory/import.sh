@@ -0,0 +1 @@
+terraform import opentoolchain_integration_artifactory.repo <integration_id>/<toolchain_id>/<env_id>
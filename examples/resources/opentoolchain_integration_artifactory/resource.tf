@@ -0,0 +1,11 @@
+resource "opentoolchain_integration_artifactory" "repo" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = "ibm:yp:us-east"
+  name            = "artifactory-npm"
+  server_url      = "https://artifactory.example.com"
+  repository_type = "npm"
+  user_id         = "deployer"
+  token           = var.artifactory_token
+  release_url     = "https://artifactory.example.com/artifactory/api/npm/npm-release"
+  mirror_url      = "https://artifactory.example.com/artifactory/api/npm/npm-virtual"
+}
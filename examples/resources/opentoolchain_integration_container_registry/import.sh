@@ -0,0 +1 @@
+terraform import opentoolchain_integration_container_registry.icr <integration_id>/<toolchain_id>/<env_id>
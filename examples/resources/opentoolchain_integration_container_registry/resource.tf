@@ -0,0 +1,8 @@
+resource "opentoolchain_integration_container_registry" "icr" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = "ibm:yp:us-east"
+  name            = "icr"
+  instance_name   = "container-registry"
+  instance_region = "ibm:yp:us-east"
+  resource_group  = "default"
+}
@@ -0,0 +1 @@
+terraform import opentoolchain_integration_cos.cos <integration_id>/<toolchain_id>/<env_id>
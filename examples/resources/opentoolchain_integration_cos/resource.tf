@@ -0,0 +1,6 @@
+resource "opentoolchain_integration_cos" "cos" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  name         = "evidence"
+  instance_crn = "crn:v1:bluemix:public:cloud-object-storage:global:a/xxxx:yyyy::"
+}
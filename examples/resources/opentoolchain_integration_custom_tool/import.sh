@@ -0,0 +1 @@
+terraform import opentoolchain_integration_custom_tool.grafana <integration_id>/<toolchain_id>/<env_id>
@@ -0,0 +1,14 @@
+resource "opentoolchain_integration_custom_tool" "grafana" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = "ibm:yp:us-east"
+  name            = "grafana"
+  type            = "Grafana"
+  description     = "Service dashboards"
+  url             = "https://grafana.example.com/d/service"
+  image_url       = "https://grafana.example.com/public/img/grafana_icon.svg"
+  lifecycle_phase = "MANAGE"
+
+  additional_properties = {
+    team = "sre"
+  }
+}
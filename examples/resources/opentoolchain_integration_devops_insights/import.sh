@@ -0,0 +1 @@
+terraform import opentoolchain_integration_devops_insights.insights <integration_id>/<toolchain_id>/<env_id>
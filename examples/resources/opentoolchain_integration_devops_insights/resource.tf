@@ -0,0 +1,4 @@
+resource "opentoolchain_integration_devops_insights" "insights" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+}
@@ -0,0 +1 @@
+terraform import opentoolchain_integration_event_notifications.en <integration_id>/<toolchain_id>/<env_id>
@@ -0,0 +1,7 @@
+resource "opentoolchain_integration_event_notifications" "en" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  name         = "event-notifications"
+  instance_crn = "crn:v1:bluemix:public:event-notifications:us-east:a/xxxx:yyyy::"
+  description  = "Toolchain events"
+}
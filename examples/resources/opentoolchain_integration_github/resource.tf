@@ -4,3 +4,16 @@ resource "opentoolchain_integration_github" "gt" {
   enable_issues = true
   repo_url     = "https://github.com/<account>/repository"
 }
+
+# fork repository on GitHub Enterprise server using personal access token
+resource "opentoolchain_integration_github" "ghe" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = opentoolchain_toolchain.tc.env_id
+  server_url      = "https://github.example.com"
+  repo_type       = "fork"
+  source_repo_url = "https://github.example.com/<org>/template"
+  repo_name       = "repository"
+  owner_id        = "<org>"
+  auth_type       = "pat"
+  api_token       = var.github_token
+}
@@ -0,0 +1,20 @@
+resource "opentoolchain_integration_gitlab" "gl" {
+  toolchain_id  = opentoolchain_toolchain.tc.guid
+  env_id        = opentoolchain_toolchain.tc.env_id
+  enable_issues = true
+  repo_url      = "https://gitlab.com/<group>/repository"
+}
+
+# clone repository on self-managed GitLab server using personal access token
+resource "opentoolchain_integration_gitlab" "clone" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = opentoolchain_toolchain.tc.env_id
+  server_url      = "https://gitlab.example.com"
+  repo_type       = "clone"
+  source_repo_url = "https://gitlab.example.com/<group>/template"
+  repo_name       = "repository"
+  owner_id        = "<group>"
+  private         = true
+  auth_type       = "pat"
+  api_token       = var.gitlab_token
+}
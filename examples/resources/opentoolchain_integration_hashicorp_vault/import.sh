@@ -0,0 +1 @@
+terraform import opentoolchain_integration_hashicorp_vault.vault <integration_id>/<toolchain_id>/<env_id>
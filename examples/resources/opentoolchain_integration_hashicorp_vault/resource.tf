@@ -0,0 +1,10 @@
+resource "opentoolchain_integration_hashicorp_vault" "vault" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  name         = "vault-integration"
+  server_url   = "https://vault.example.com:8200"
+  path         = "secret/data/toolchain"
+  auth_method  = "approle"
+  role_id      = var.vault_role_id
+  secret_id    = var.vault_secret_id
+}
@@ -0,0 +1 @@
+terraform import opentoolchain_integration_hostedgit.hg <integration_id>/<toolchain_id>/<env_id>
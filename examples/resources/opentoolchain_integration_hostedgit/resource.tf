@@ -0,0 +1,15 @@
+resource "opentoolchain_integration_hostedgit" "hg" {
+  toolchain_id  = opentoolchain_toolchain.tc.guid
+  env_id        = opentoolchain_toolchain.tc.env_id
+  enable_issues = true
+  repo_url      = "https://us-south.git.cloud.ibm.com/<owner>/repository"
+}
+
+# create new private repository
+resource "opentoolchain_integration_hostedgit" "new" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = opentoolchain_toolchain.tc.env_id
+  repo_type    = "new"
+  repo_name    = "repository"
+  owner_id     = "<owner>"
+}
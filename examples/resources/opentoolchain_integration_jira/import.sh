@@ -0,0 +1 @@
+terraform import opentoolchain_integration_jira.jira <integration_id>/<toolchain_id>/<env_id>
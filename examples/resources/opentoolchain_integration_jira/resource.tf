@@ -0,0 +1,9 @@
+resource "opentoolchain_integration_jira" "jira" {
+  toolchain_id        = opentoolchain_toolchain.tc.guid
+  env_id              = "ibm:yp:us-east"
+  server_url          = "https://example.atlassian.net"
+  project_key         = "PRJ"
+  user                = "user@example.com"
+  api_token           = var.jira_api_token
+  enable_traceability = true
+}
@@ -6,3 +6,11 @@ resource "opentoolchain_integration_keyprotect" "kp" {
   instance_name = "kp-instance-dev"
   name = "kp-integration"
 }
+
+# instance can also be referenced by CRN
+resource "opentoolchain_integration_keyprotect" "kp_crn" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  instance_crn = ibm_resource_instance.kp.crn
+  name         = "kp-integration-crn"
+}
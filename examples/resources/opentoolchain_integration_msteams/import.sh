@@ -0,0 +1 @@
+terraform import opentoolchain_integration_msteams.notifications <integration_id>/<toolchain_id>/<env_id>
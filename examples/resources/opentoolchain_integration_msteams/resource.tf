@@ -0,0 +1,14 @@
+resource "opentoolchain_integration_msteams" "notifications" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  name         = "msteams"
+  webhook_url  = var.msteams_webhook_url
+
+  events {
+    pipeline_start   = false
+    pipeline_success = true
+    pipeline_fail    = true
+    toolchain_bind   = false
+    toolchain_unbind = false
+  }
+}
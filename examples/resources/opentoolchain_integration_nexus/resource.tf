@@ -0,0 +1,11 @@
+resource "opentoolchain_integration_nexus" "repo" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = "ibm:yp:us-east"
+  name            = "nexus-maven"
+  server_url      = "https://nexus.example.com"
+  repository_type = "maven"
+  user_id         = "deployer"
+  token           = var.nexus_token
+  release_url     = "https://nexus.example.com/repository/maven-releases"
+  mirror_url      = "https://nexus.example.com/repository/maven-public"
+}
@@ -0,0 +1 @@
+terraform import opentoolchain_integration_private_worker.worker <integration_id>/<toolchain_id>/<env_id>
@@ -0,0 +1,6 @@
+resource "opentoolchain_integration_private_worker" "worker" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  name         = "private-worker"
+  api_key      = var.worker_api_key
+}
@@ -0,0 +1 @@
+terraform import opentoolchain_integration_secrets_manager.sm <integration_id>/<toolchain_id>/<env_id>
@@ -0,0 +1,16 @@
+resource "opentoolchain_integration_secrets_manager" "sm" {
+  toolchain_id    = opentoolchain_toolchain.tc.guid
+  env_id          = "ibm:yp:us-east"
+  resource_group  = data.ibm_resource_group.rg.name
+  instance_region = "ibm:yp:us-east"
+  instance_name   = "sm-instance-dev"
+  name            = "sm-integration"
+}
+
+resource "opentoolchain_tekton_pipeline" "tp" {
+  # ...
+
+  secret_env = {
+    API_KEY = "${opentoolchain_integration_secrets_manager.sm.secret_reference_prefix}api-key}"
+  }
+}
@@ -4,9 +4,11 @@ resource "opentoolchain_integration_slack" "sl" {
   webhook_url  = "https://hooks.slack.com/services/XXXXXXXX"
   channel_name = "notifications"
   team_name = "yourslackteam"
+  send_test_message = true
 
   events {
     toolchain_bind = false
     toolchain_unbind = false
+    pipeline_task_fail = true
   }
 }
@@ -0,0 +1 @@
+terraform import opentoolchain_integration_sonarqube.sq <integration_id>/<toolchain_id>/<env_id>
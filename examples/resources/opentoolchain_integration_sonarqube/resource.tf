@@ -0,0 +1,8 @@
+resource "opentoolchain_integration_sonarqube" "sq" {
+  toolchain_id     = opentoolchain_toolchain.tc.guid
+  env_id           = "ibm:yp:us-east"
+  name             = "sonarqube"
+  server_url       = "https://sonarqube.example.com"
+  token            = var.sonarqube_token
+  blind_connection = true
+}
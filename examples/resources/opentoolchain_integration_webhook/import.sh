@@ -0,0 +1 @@
+terraform import opentoolchain_integration_webhook.notifications <integration_id>/<toolchain_id>/<env_id>
@@ -0,0 +1,14 @@
+resource "opentoolchain_integration_webhook" "notifications" {
+  toolchain_id = opentoolchain_toolchain.tc.guid
+  env_id       = "ibm:yp:us-east"
+  name         = "webhook"
+  webhook_url  = var.webhook_webhook_url
+
+  events {
+    pipeline_start   = false
+    pipeline_success = true
+    pipeline_fail    = true
+    toolchain_bind   = false
+    toolchain_unbind = false
+  }
+}
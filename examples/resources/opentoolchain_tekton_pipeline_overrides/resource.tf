@@ -19,4 +19,16 @@ resource "opentoolchain_tekton_pipeline_overrides" "ov" {
         name = "Git Trigger"
         enabled = false
     }
+
+    # new trigger, removed when this resource is destroyed
+    trigger {
+        name = "Feature Trigger"
+        enabled = true
+        type = "scm"
+        event_listener = "ci-git-push"
+        github_integration_guid = var.github_integration_guid
+        github_url = var.github_url
+        pattern = "feature/*"
+        on_push = true
+    }
 }
@@ -0,0 +1,2 @@
+# triggers can only be read through their pipeline, so pipeline and environment IDs are required
+terraform import opentoolchain_tekton_pipeline_trigger.feature <trigger_id>/<pipeline_id>/<env_id>
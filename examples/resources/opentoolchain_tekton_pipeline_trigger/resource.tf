@@ -0,0 +1,11 @@
+resource "opentoolchain_tekton_pipeline_trigger" "feature" {
+  pipeline_id           = opentoolchain_tekton_pipeline.tp.pipeline_id
+  env_id                = "ibm:yp:us-east"
+  name                  = "Feature Branch Trigger"
+  event_listener        = "ci-git-push"
+  type                  = "scm"
+  github_integration_id = opentoolchain_integration_github.gi.integration_id
+  github_url            = opentoolchain_integration_github.gi.repo_url
+  pattern               = "feature/*"
+  on_push               = true
+}
@@ -6,7 +6,7 @@ go 1.17
 
 require (
 	github.com/IBM/go-sdk-core v1.1.0
-	github.com/IBM/go-sdk-core/v5 v5.9.1 // indirect
+	github.com/IBM/go-sdk-core/v5 v5.9.1
 	github.com/IBM/platform-services-go-sdk v0.22.6
 	github.com/agext/levenshtein v1.2.3 // indirect
 	github.com/apparentlymart/go-cidr v1.1.0 // indirect
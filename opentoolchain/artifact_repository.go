@@ -0,0 +1,75 @@
+package opentoolchain
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strings"
+)
+
+// Artifactory and Nexus integrations share the same parameters, except for server URL parameter name
+// and supported repository types
+func newArtifactRepositoryIntegration(serviceType string, title string, serverURLParam string, repositoryTypes []string) *serviceInstanceIntegration {
+	return &serviceInstanceIntegration{
+		serviceType: serviceType,
+		title:       title,
+		schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Integration name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"server_url": {
+				Description:  fmt.Sprintf("%s server URL", title),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+			"repository_type": {
+				Description:  fmt.Sprintf("Repository type: `%s`", strings.Join(repositoryTypes, "`, `")),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(repositoryTypes, false),
+			},
+			"user_id": {
+				Description: fmt.Sprintf("%s user ID", title),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"token": {
+				Description:  fmt.Sprintf("%s API token or password, use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.", title),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateSecretReference,
+			},
+			"release_url": {
+				Description:  "Release repository URL",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+			"mirror_url": {
+				Description:  "Mirror repository URL",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+		},
+		params: map[string]string{
+			"name":            "name",
+			"server_url":      serverURLParam,
+			"repository_type": "type",
+			"user_id":         "user_id",
+			"release_url":     "release_url",
+			"mirror_url":      "mirror_url",
+		},
+		secrets: map[string]string{
+			"token": "token",
+		},
+		nameParam: "name",
+		customizeDiff: []schema.CustomizeDiffFunc{
+			customizeSecretReferencesDiff("token", "", getSecretReferencesToolchainID),
+		},
+	}
+}
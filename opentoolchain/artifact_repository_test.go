@@ -0,0 +1,45 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArtifactRepositoryFlattenParams(t *testing.T) {
+	params := map[string]interface{}{
+		"name":          "artifactory",
+		"dashboard_url": "https://artifactory.example.com",
+		"server_url":    "https://nexus.example.com",
+		"type":          "npm",
+		"user_id":       "user",
+		"token":         "encrypted",
+		"release_url":   "https://artifactory.example.com/npm-release",
+		"mirror_url":    "",
+	}
+
+	expected := map[string]interface{}{
+		"name":            "artifactory",
+		"server_url":      "https://artifactory.example.com",
+		"repository_type": "npm",
+		"user_id":         "user",
+		"release_url":     "https://artifactory.example.com/npm-release",
+		"mirror_url":      "",
+	}
+
+	assert.Equal(t, expected, artifactoryIntegration.flattenParams(params))
+
+	expected["server_url"] = "https://nexus.example.com"
+	assert.Equal(t, expected, nexusIntegration.flattenParams(params))
+}
+
+func TestArtifactRepositoryDataSourceSchema(t *testing.T) {
+	s := dataSourceOpenToolchainIntegrationArtifactory().Schema
+
+	assert.True(t, s["integration_id"].Optional)
+	assert.Equal(t, []string{"name"}, s["integration_id"].ConflictsWith)
+	assert.True(t, s["name"].Optional)
+	assert.True(t, s["server_url"].Computed)
+	assert.False(t, s["server_url"].Optional)
+	assert.NotContains(t, s, "token")
+	assert.NotContains(t, s, "encrypted_token")
+}
@@ -0,0 +1,154 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+)
+
+// newCloudInstanceIntegration returns integration for IBM Cloud service instance, referenced either by CRN
+// or by name, region and resource group
+func newCloudInstanceIntegration(serviceType string, title string) *serviceInstanceIntegration {
+	return &serviceInstanceIntegration{
+		serviceType: serviceType,
+		title:       title,
+		schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Integration name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"instance_name": {
+				Description:  fmt.Sprintf("%s instance name, requires `instance_region` and `resource_group`", title),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"instance_name", "instance_crn"},
+				RequiredWith: []string{"instance_region", "resource_group"},
+			},
+			"instance_crn": {
+				Description:  fmt.Sprintf("%s instance CRN", title),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"instance_name", "instance_crn"},
+			},
+			"instance_region": {
+				Description: fmt.Sprintf("%s instance region, example: `ibm:yp:us-east`", title),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"resource_group": {
+				Description: fmt.Sprintf("The name of the resource group of %s instance", title),
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+		params: map[string]string{
+			"name": "name",
+		},
+		nameParam: "name",
+		expand:    expandCloudInstanceParams,
+		flatten:   flattenCloudInstanceParams,
+	}
+}
+
+func expandCloudInstanceParams(d *schema.ResourceData, params map[string]interface{}) {
+	if crn, ok := d.GetOk("instance_crn"); ok {
+		params["instance-id-type"] = "instance-crn"
+		params["instance-crn"] = crn.(string)
+	} else {
+		params["instance-id-type"] = "instance-name"
+		params["instance-name"] = d.Get("instance_name").(string)
+		params["region"] = d.Get("instance_region").(string)
+		params["resource-group"] = d.Get("resource_group").(string)
+	}
+}
+
+func flattenCloudInstanceParams(params map[string]interface{}, result map[string]interface{}) {
+	for param, attr := range map[string]string{
+		"instance-name":  "instance_name",
+		"instance-crn":   "instance_crn",
+		"region":         "instance_region",
+		"resource-group": "resource_group",
+	} {
+		if v, ok := params[param].(string); ok && v != "" {
+			result[attr] = v
+		}
+	}
+}
+
+// customizeCloudInstanceDiff validates that referenced instance exists, using resource controller
+func customizeCloudInstanceDiff(serviceName string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config, ok := meta.(*ProviderConfig)
+
+		if !ok || config.ResourceControllerClient == nil {
+			return nil
+		}
+
+		if d.Id() != "" && !d.HasChange("instance_crn") && !d.HasChange("instance_name") && !d.HasChange("instance_region") && !d.HasChange("resource_group") {
+			return nil
+		}
+
+		if !d.NewValueKnown("instance_crn") {
+			// validate during apply
+			return nil
+		}
+
+		if crn := d.Get("instance_crn").(string); crn != "" {
+			_, err := getResourceInstanceByCRN(ctx, config.ResourceControllerClient, crn, serviceName)
+			return err
+		}
+
+		if !d.NewValueKnown("instance_name") || !d.NewValueKnown("instance_region") || !d.NewValueKnown("resource_group") {
+			return nil
+		}
+
+		name := d.Get("instance_name").(string)
+		region := d.Get("instance_region").(string)
+		resourceGroup := d.Get("resource_group").(string)
+
+		if name == "" || region == "" || resourceGroup == "" {
+			return nil
+		}
+
+		_, err := findResourceInstance(ctx, config, name, region, resourceGroup, serviceName)
+		return err
+	}
+}
+
+// readCloudInstance sets `instance_guid` and `dashboard_url` attributes
+func readCloudInstance(serviceName string) func(ctx context.Context, config *ProviderConfig, d *schema.ResourceData, svc *oc.GetServiceInstanceResponseServiceInstance, integrationID string, toolchainID string, envID string) error {
+	return func(ctx context.Context, config *ProviderConfig, d *schema.ResourceData, svc *oc.GetServiceInstanceResponseServiceInstance, integrationID string, toolchainID string, envID string) error {
+		if svc != nil && svc.DashboardURL != nil {
+			d.Set("dashboard_url", *svc.DashboardURL)
+		}
+
+		if crn := d.Get("instance_crn").(string); crn != "" {
+			d.Set("instance_guid", getCRNServiceInstance(crn))
+			return nil
+		}
+
+		if config.ResourceControllerClient == nil {
+			return nil
+		}
+
+		instance, err := findResourceInstance(ctx, config, d.Get("instance_name").(string), d.Get("instance_region").(string), d.Get("resource_group").(string), serviceName)
+
+		if err != nil {
+			// instance might have been renamed or removed, integration itself is still valid
+			log.Printf("[WARN] Unable to resolve %s instance GUID: %s", serviceName, err)
+			d.Set("instance_guid", "")
+			return nil
+		}
+
+		if instance.GUID != nil {
+			d.Set("instance_guid", *instance.GUID)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package opentoolchain
+
+import (
+	"context"
+	"github.com/IBM/go-sdk-core/v5/core"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCustomizeCloudInstanceDiffCRN(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	rcClient, err := rc.NewResourceControllerV2(&rc.ResourceControllerV2Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+
+	assert.NoError(t, err)
+
+	// only instance_crn is set, instance_region and resource_group are computed and unknown during planning
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"toolchain_id": "toolchain",
+		"env_id":       "ibm:yp:us-south",
+		"name":         "kp",
+		"instance_crn": "crn:v1:bluemix:public:kms:us-south:a/0123456789:1f2d3c4b-5a69-4786-9504-d3c2b1a09f8e::",
+	})
+
+	_, err = resourceOpenToolchainIntegrationKeyProtect().Diff(context.Background(), nil, config, &ProviderConfig{ResourceControllerClient: rcClient})
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "not found")
+	}
+
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationAppConfiguration() *schema.Resource {
+	return appConfigurationIntegration.dataSource("Get IBM Cloud App Configuration integration information (WARN: using undocumented APIs)")
+}
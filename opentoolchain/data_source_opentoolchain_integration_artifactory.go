@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationArtifactory() *schema.Resource {
+	return artifactoryIntegration.dataSource("Get Artifactory integration information (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationContainerRegistry() *schema.Resource {
+	return containerRegistryIntegration.dataSource("Get IBM Cloud Container Registry integration information (WARN: using undocumented APIs)")
+}
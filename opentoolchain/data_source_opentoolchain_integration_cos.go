@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationCOS() *schema.Resource {
+	return cosIntegration.dataSource("Get IBM Cloud Object Storage integration information (WARN: using undocumented APIs)")
+}
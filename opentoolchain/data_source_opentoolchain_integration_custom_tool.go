@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationCustomTool() *schema.Resource {
+	return customToolIntegration.dataSource("Get custom tool (\"Other Tool\") integration information (WARN: using undocumented APIs)")
+}
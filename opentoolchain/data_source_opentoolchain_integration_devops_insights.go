@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationDevOpsInsights() *schema.Resource {
+	return devopsInsightsIntegration.dataSource("Get DevOps Insights integration information (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationEventNotifications() *schema.Resource {
+	return eventNotificationsIntegration.dataSource("Get IBM Cloud Event Notifications integration information (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,125 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationGitlab() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get GitLab integration information (WARN: using undocumented APIs)",
+		ReadContext: dataSourceOpenToolchainIntegrationGitlabRead,
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"integration_id": {
+				Description:   "The integration `guid`, if not set, the only integration matching `repo_url` is used",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"repo_url"},
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"server_url": {
+				Description: "GitLab server URL",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"repo_type": {
+				Description: "Repository type: `link`, `clone`, `fork` or `new`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"repo_url": {
+				Description:   "GitLab repository url",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"integration_id"},
+			},
+			"source_repo_url": {
+				Description: "Source repository url for cloned or forked repositories",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"repo_name": {
+				Description: "Repository name",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"owner_id": {
+				Description: "GitLab user or group that owns the repository",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"private": {
+				Description: "`true` if repository is private",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"enable_issues": {
+				Description: "`true` if GitLab issues are enabled",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"enable_traceability": {
+				Description: "`true` if tracking for deployment of code changes is enabled",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"auth_type": {
+				Description: "Authentication type: `oauth` or personal access token (`pat`)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceOpenToolchainIntegrationGitlabRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	integrationID, err := getDataSourceIntegrationID(ctx, c, d, gitlabIntegrationServiceType, gitRepoURLLookup)
+
+	if err != nil {
+		return diag.Errorf("Error looking up gitlab integration: %s", err)
+	}
+
+	d.Set("integration_id", integrationID)
+
+	svc, _, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error reading gitlab service instance: %s", err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		for k, v := range flattenGitIntegrationParams(gitlabGitID, gitlabDefaultServerURL, svc.ServiceInstance.Parameters) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting gitlab integration %s: %s", k, err)
+			}
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var hashicorpVaultIntegrationLookups = []integrationLookup{
+	{attr: "name", param: "name"},
+}
+
+func dataSourceOpenToolchainIntegrationHashicorpVault() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get HashiCorp Vault integration information (WARN: using undocumented APIs)",
+		ReadContext: dataSourceOpenToolchainIntegrationHashicorpVaultRead,
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"integration_id": {
+				Description:   "The integration `guid`, if not set, the only integration matching `name` is used",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description:   "Integration name",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"integration_id"},
+			},
+			"server_url": {
+				Description: "HashiCorp Vault server URL",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"dashboard_url": {
+				Description: "HashiCorp Vault dashboard URL",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"path": {
+				Description: "Path to the secrets engine",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"auth_method": {
+				Description: "Authentication method: `token`, `approle` or `userpass`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"username": {
+				Description: "Vault username, if `auth_method` is `userpass`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"secret_reference_prefix": {
+				Description: "Prefix for secret references",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceOpenToolchainIntegrationHashicorpVaultRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	integrationID, err := getDataSourceIntegrationID(ctx, c, d, hashicorpVaultIntegrationServiceType, hashicorpVaultIntegrationLookups)
+
+	if err != nil {
+		return diag.Errorf("Error looking up hashicorp vault integration: %s", err)
+	}
+
+	d.Set("integration_id", integrationID)
+
+	svc, _, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error reading hashicorp vault service instance: %s", err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		for k, v := range flattenHashicorpVaultIntegrationParams(svc.ServiceInstance.Parameters) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting hashicorp vault integration %s: %s", k, err)
+			}
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationHostedGit() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get IBM Git Repos and Issue Tracking integration information (WARN: using undocumented APIs)",
+		ReadContext: dataSourceOpenToolchainIntegrationHostedGitRead,
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"integration_id": {
+				Description:   "The integration `guid`, if not set, the only integration matching `repo_url` is used",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"repo_url"},
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"repo_type": {
+				Description: "Repository type: `existing`, `clone` or `new`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"repo_url": {
+				Description:   "Repository url",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"integration_id"},
+			},
+			"source_repo_url": {
+				Description: "Source repository url for cloned repositories",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"repo_name": {
+				Description: "Repository name",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"owner_id": {
+				Description: "User or group that owns the repository",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"private": {
+				Description: "`true` if repository is private",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"enable_issues": {
+				Description: "`true` if issue tracking is enabled",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"enable_traceability": {
+				Description: "`true` if tracking for deployment of code changes is enabled",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceOpenToolchainIntegrationHostedGitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	integrationID, err := getDataSourceIntegrationID(ctx, c, d, hostedGitIntegrationServiceType, gitRepoURLLookup)
+
+	if err != nil {
+		return diag.Errorf("Error looking up hosted git integration: %s", err)
+	}
+
+	d.Set("integration_id", integrationID)
+
+	svc, _, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error reading hosted git service instance: %s", err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		for k, v := range flattenHostedGitIntegrationParams(svc.ServiceInstance.Parameters) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting hosted git integration %s: %s", k, err)
+			}
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
+
+	return nil
+}
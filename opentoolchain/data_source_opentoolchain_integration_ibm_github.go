@@ -19,9 +19,11 @@ func dataSourceOpenToolchainIntegrationIBMGithub() *schema.Resource {
 				Required:    true,
 			},
 			"integration_id": {
-				Description: "The integration `guid`",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:   "The integration `guid`, if not set, the only integration matching `repo_url` is used",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"repo_url"},
 			},
 			"env_id": {
 				Description: "Environment ID, example: `ibm:yp:us-south`",
@@ -29,9 +31,11 @@ func dataSourceOpenToolchainIntegrationIBMGithub() *schema.Resource {
 				Required:    true,
 			},
 			"repo_url": {
-				Description: "Github repository url",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:   "Github repository url",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"integration_id"},
 			},
 			"private": {
 				Description: "`true` if repository is private",
@@ -56,11 +60,18 @@ func dataSourceOpenToolchainIntegrationIBMGithub() *schema.Resource {
 func dataSourceOpenToolchainIntegrationIBMGithubRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
-	integrationID := d.Get("integration_id").(string)
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
+	integrationID, err := getDataSourceIntegrationID(ctx, c, d, ibmGithubIntegrationServiceType, gitRepoURLLookup)
+
+	if err != nil {
+		return diag.Errorf("Error looking up github integration: %s", err)
+	}
+
+	d.Set("integration_id", integrationID)
+
 	svc, _, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
 		EnvID:       &envID,
 		ToolchainID: &toolchainID,
@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationJira() *schema.Resource {
+	return jiraIntegration.dataSource("Get Jira integration information (WARN: using undocumented APIs)")
+}
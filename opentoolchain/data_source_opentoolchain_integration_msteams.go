@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationMSTeams() *schema.Resource {
+	return msteamsIntegration.dataSource("Get Microsoft Teams integration information (WARN: using undocumented APIs)")
+}
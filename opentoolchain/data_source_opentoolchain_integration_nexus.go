@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationNexus() *schema.Resource {
+	return nexusIntegration.dataSource("Get Nexus integration information (WARN: using undocumented APIs)")
+}
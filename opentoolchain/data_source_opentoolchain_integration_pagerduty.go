@@ -8,6 +8,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var pagerDutyIntegrationLookups = []integrationLookup{
+	{attr: "service_name", param: "service_name"},
+}
+
 func dataSourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 	return &schema.Resource{
 		Description: "Get PagerDuty integration information (WARN: using undocumented APIs)",
@@ -19,9 +23,11 @@ func dataSourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 				Required:    true,
 			},
 			"integration_id": {
-				Description: "The integration `guid`",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:   "The integration `guid`, if not set, the only integration matching `service_name` is used",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"service_name"},
 			},
 			"env_id": {
 				Description: "Environment ID, example: `ibm:yp:us-south`",
@@ -39,17 +45,24 @@ func dataSourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 				Computed:    true,
 			},
 			"service_name": {
-				Description: "Name of PagerDuty service to post alerts to",
+				Description:   "Name of PagerDuty service to post alerts to",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"integration_id"},
+			},
+			"escalation_policy_id": {
+				Description: "ID of PagerDuty escalation policy used by the service",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
 			"primary_email": {
-				Description: "The email address of the user to contact when alert is posted (required if `service_name` is specified)",
+				Description: "The email address of the user to contact when alert is posted",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
 			"primary_phone_number": {
-				Description: "The phone number of the user to contact when alert is posted. If national code is omitted, `+1` is set by default (required if `service_name` is specified)",
+				Description: "The phone number of the user to contact when alert is posted, in E.164 format",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
@@ -60,11 +73,18 @@ func dataSourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 func dataSourceOpenToolchainIntegrationPagerDutyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
-	integrationID := d.Get("integration_id").(string)
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
+	integrationID, err := getDataSourceIntegrationID(ctx, c, d, pagerDutyIntegrationServiceType, pagerDutyIntegrationLookups)
+
+	if err != nil {
+		return diag.Errorf("Error looking up pagerduty integration: %s", err)
+	}
+
+	d.Set("integration_id", integrationID)
+
 	svc, _, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
 		EnvID:       &envID,
 		ToolchainID: &toolchainID,
@@ -97,6 +117,10 @@ func dataSourceOpenToolchainIntegrationPagerDutyRead(ctx context.Context, d *sch
 		if p, ok := params["user_phone"]; ok {
 			d.Set("primary_phone_number", p.(string))
 		}
+
+		if p, ok := params["escalation_policy_id"].(string); ok {
+			d.Set("escalation_policy_id", p)
+		}
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationPrivateWorker() *schema.Resource {
+	return privateWorkerIntegration.dataSource("Get Private Worker integration information (WARN: using undocumented APIs)")
+}
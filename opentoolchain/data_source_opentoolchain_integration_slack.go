@@ -8,6 +8,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var slackIntegrationLookups = []integrationLookup{
+	{attr: "channel_name", param: "channel_name"},
+}
+
 func dataSourceOpenToolchainIntegrationSlack() *schema.Resource {
 	return &schema.Resource{
 		Description: "Get IBM Slack integration information (WARN: using undocumented APIs)",
@@ -19,9 +23,11 @@ func dataSourceOpenToolchainIntegrationSlack() *schema.Resource {
 				Required:    true,
 			},
 			"integration_id": {
-				Description: "The integration `guid`",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:   "The integration `guid`, if not set, the only integration matching `channel_name` is used",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"channel_name"},
 			},
 			"env_id": {
 				Description: "Environment ID, example: `ibm:yp:us-south`",
@@ -29,9 +35,11 @@ func dataSourceOpenToolchainIntegrationSlack() *schema.Resource {
 				Required:    true,
 			},
 			"channel_name": {
-				Description: "Slack channel name",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:   "Slack channel name",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"integration_id"},
 			},
 			"team_name": {
 				Description: "Slack team name, the phrase before `.slack.com`, for example if your team URL is https://team.slack.com, the team name is `team`",
@@ -42,35 +50,7 @@ func dataSourceOpenToolchainIntegrationSlack() *schema.Resource {
 				Description: "Events for which you want to receive notifications",
 				Type:        schema.TypeList,
 				Computed:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"pipeline_start": {
-							Description: "Send slack notification when pipeline is started",
-							Type:        schema.TypeBool,
-							Computed:    true,
-						},
-						"pipeline_success": {
-							Description: "Send slack notification when pipeline succeeds",
-							Type:        schema.TypeBool,
-							Computed:    true,
-						},
-						"pipeline_fail": {
-							Description: "Send slack notification when pipeline fails",
-							Type:        schema.TypeBool,
-							Computed:    true,
-						},
-						"toolchain_bind": {
-							Description: "Send slack notification when integration is created",
-							Type:        schema.TypeBool,
-							Computed:    true,
-						},
-						"toolchain_unbind": {
-							Description: "Send slack notification when integration is removed",
-							Type:        schema.TypeBool,
-							Computed:    true,
-						},
-					},
-				},
+				Elem:        computedElem(notificationEventsSchema("slack").Elem),
 			},
 		},
 	}
@@ -79,11 +59,18 @@ func dataSourceOpenToolchainIntegrationSlack() *schema.Resource {
 func dataSourceOpenToolchainIntegrationSlackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
-	integrationID := d.Get("integration_id").(string)
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
+	integrationID, err := getDataSourceIntegrationID(ctx, c, d, slackIntegrationServiceType, slackIntegrationLookups)
+
+	if err != nil {
+		return diag.Errorf("Error looking up slack integration: %s", err)
+	}
+
+	d.Set("integration_id", integrationID)
+
 	svc, _, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
 		EnvID:       &envID,
 		ToolchainID: &toolchainID,
@@ -95,25 +82,10 @@ func dataSourceOpenToolchainIntegrationSlackRead(ctx context.Context, d *schema.
 	}
 
 	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
-		params := svc.ServiceInstance.Parameters
-		events := []interface{}{map[string]bool{
-			"pipeline_start":   params["pipeline_start"].(bool),
-			"pipeline_success": params["pipeline_success"].(bool),
-			"pipeline_fail":    params["pipeline_fail"].(bool),
-			"toolchain_bind":   params["toolchain_bind"].(bool),
-			"toolchain_unbind": params["toolchain_unbind"].(bool),
-		}}
-
-		if err := d.Set("events", events); err != nil {
-			return diag.Errorf("Error setting slack integration events: %s", err)
-		}
-
-		if n, ok := params["channel_name"]; ok {
-			d.Set("channel_name", n.(string))
-		}
-
-		if t, ok := params["team_url"]; ok {
-			d.Set("team_name", t.(string))
+		for k, v := range flattenSlackIntegrationParams(svc.ServiceInstance.Parameters) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting slack integration %s: %s", k, err)
+			}
 		}
 	}
 
@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationSonarQube() *schema.Resource {
+	return sonarqubeIntegration.dataSource("Get SonarQube integration information (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,9 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpenToolchainIntegrationWebhook() *schema.Resource {
+	return webhookIntegration.dataSource("Get Webhook integration information (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,261 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+// sensitiveServiceParameters are never returned by `opentoolchain_integrations`,
+// API only provides encrypted values for these anyway
+var sensitiveServiceParameters = map[string]bool{
+	"api_key":                true,
+	"api_token":              true,
+	"password":               true,
+	"role_id":                true,
+	"secret_id":              true,
+	"service_key":            true,
+	"token":                  true,
+	"user_password":          true,
+	"workerQueueCredentials": true,
+}
+
+// serviceNameParameters are checked in order to determine integration name, different brokers use different parameters
+var serviceNameParameters = []string{"name", "instance_name", "label"}
+
+func dataSourceOpenToolchainIntegrations() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get toolchain integrations, optionally filtered by service ID, name or parameter values",
+		ReadContext: dataSourceOpenToolchainIntegrationsRead,
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"service_id": {
+				Description: "Only return integrations of this service type, for example `githubconsolidated` or `keyprotect`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name": {
+				Description: fmt.Sprintf("Only return integrations with this name, matched against `%s` parameters", strings.Join(serviceNameParameters, "`, `")),
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"parameters": {
+				Description: "Only return integrations with matching parameter values, for example `{ repo_url = \"https://github.com/org/repo\" }`",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"integrations": {
+				Description: "Matching integrations",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"integration_id": {
+							Description: "The integration `guid`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"service_id": {
+							Description: "Integration service type",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"broker_id": {
+							Description: "Service broker ID",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Integration name, if service type has one",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"parameters": {
+							Description: "Non-sensitive integration parameters, nested values are omitted",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"ids": {
+				Description: "Matching integration `guid` list",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpenToolchainIntegrationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toolchainID := d.Get("toolchain_id").(string)
+	envID := d.Get("env_id").(string)
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	svcs, err := getToolchainServices(ctx, c, toolchainID, region)
+
+	if err != nil {
+		return diag.Errorf("Error reading toolchain services: %s", err)
+	}
+
+	filter := &serviceFilter{
+		serviceID:  d.Get("service_id").(string),
+		name:       d.Get("name").(string),
+		parameters: d.Get("parameters").(map[string]interface{}),
+	}
+
+	services := filter.apply(svcs)
+
+	if err := d.Set("integrations", flattenIntegrations(services)); err != nil {
+		return diag.Errorf("Error setting integrations: %s", err)
+	}
+
+	var ids []string
+
+	for _, svc := range services {
+		ids = append(ids, *svc.InstanceID)
+	}
+
+	d.Set("ids", ids)
+	d.SetId(fmt.Sprintf("%s/%s", toolchainID, envID))
+
+	return nil
+}
+
+// serviceFilter matches toolchain services, empty fields match any service
+type serviceFilter struct {
+	serviceID  string
+	name       string
+	parameters map[string]interface{}
+	// optional parameter value normalization, applied to both filter and service parameter values
+	normalize map[string]func(string) string
+}
+
+func (f *serviceFilter) apply(svcs []oc.Service) []oc.Service {
+	var result []oc.Service
+
+	for _, svc := range svcs {
+		if f.matches(svc) {
+			result = append(result, svc)
+		}
+	}
+
+	return result
+}
+
+func (f *serviceFilter) matches(svc oc.Service) bool {
+	// services without instance ID can't be referenced by integration resources
+	if svc.InstanceID == nil || svc.ServiceID == nil {
+		return false
+	}
+
+	if f.serviceID != "" && *svc.ServiceID != f.serviceID {
+		return false
+	}
+
+	if f.name != "" && getServiceName(svc.Parameters) != f.name {
+		return false
+	}
+
+	for k, v := range f.parameters {
+		p, ok := flattenServiceParameter(svc.Parameters[k])
+
+		if !ok {
+			return false
+		}
+
+		value := v.(string)
+
+		if n, ok := f.normalize[k]; ok {
+			p, value = n(p), n(value)
+		}
+
+		if p != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getServiceName returns the first non-empty name parameter
+func getServiceName(params map[string]interface{}) string {
+	for _, k := range serviceNameParameters {
+		if n, ok := params[k].(string); ok && n != "" {
+			return n
+		}
+	}
+
+	return ""
+}
+
+// flattenServiceParameter converts scalar parameter value to string, nested values are not supported
+func flattenServiceParameter(v interface{}) (string, bool) {
+	switch p := v.(type) {
+	case string:
+		return p, true
+	case bool, float64:
+		return fmt.Sprint(p), true
+	default:
+		return "", false
+	}
+}
+
+func flattenIntegrations(svcs []oc.Service) []interface{} {
+	var result []interface{}
+
+	for _, svc := range svcs {
+		integration := map[string]interface{}{
+			"integration_id": *svc.InstanceID,
+			"service_id":     *svc.ServiceID,
+			"name":           getServiceName(svc.Parameters),
+		}
+
+		if svc.BrokerID != nil {
+			integration["broker_id"] = *svc.BrokerID
+		}
+
+		params := make(map[string]interface{})
+
+		for k, v := range svc.Parameters {
+			if sensitiveServiceParameters[k] {
+				continue
+			}
+
+			if p, ok := flattenServiceParameter(v); ok {
+				params[k] = p
+			}
+		}
+
+		integration["parameters"] = params
+		result = append(result, integration)
+	}
+
+	return result
+}
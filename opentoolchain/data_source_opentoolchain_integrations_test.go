@@ -0,0 +1,120 @@
+package opentoolchain
+
+import (
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testToolchainServices() []oc.Service {
+	return []oc.Service{
+		{
+			ServiceID:  getStringPtr("githubconsolidated"),
+			InstanceID: getStringPtr("gh-1"),
+			Parameters: map[string]interface{}{
+				"repo_url":   "https://github.com/org/app",
+				"api_token":  "encrypted",
+				"has_issues": true,
+			},
+		},
+		{
+			ServiceID:  getStringPtr("githubconsolidated"),
+			InstanceID: getStringPtr("gh-2"),
+			Parameters: map[string]interface{}{
+				"repo_url": "https://github.com/org/lib",
+			},
+		},
+		{
+			ServiceID:  getStringPtr("keyprotect"),
+			InstanceID: getStringPtr("kp-1"),
+			BrokerID:   getStringPtr("broker"),
+			Parameters: map[string]interface{}{
+				"name":   "secrets",
+				"region": "us-south",
+			},
+		},
+		{
+			ServiceID:  getStringPtr("customtool"),
+			InstanceID: getStringPtr("ct-1"),
+			Parameters: map[string]interface{}{
+				"instance_name": "docs",
+				"additional_properties": map[string]interface{}{
+					"key": "value",
+				},
+			},
+		},
+		{
+			// template services are not yet instantiated
+			ServiceID: getStringPtr("pipeline"),
+		},
+	}
+}
+
+func getServiceInstanceIDList(svcs []oc.Service) []string {
+	var ids []string
+
+	for _, svc := range svcs {
+		ids = append(ids, *svc.InstanceID)
+	}
+
+	return ids
+}
+
+func TestServiceFilter(t *testing.T) {
+	svcs := testToolchainServices()
+
+	f := &serviceFilter{}
+	assert.Equal(t, []string{"gh-1", "gh-2", "kp-1", "ct-1"}, getServiceInstanceIDList(f.apply(svcs)))
+
+	f = &serviceFilter{serviceID: "githubconsolidated"}
+	assert.Equal(t, []string{"gh-1", "gh-2"}, getServiceInstanceIDList(f.apply(svcs)))
+
+	f = &serviceFilter{serviceID: "githubconsolidated", parameters: map[string]interface{}{"repo_url": "https://github.com/org/lib"}}
+	assert.Equal(t, []string{"gh-2"}, getServiceInstanceIDList(f.apply(svcs)))
+
+	f = &serviceFilter{parameters: map[string]interface{}{"has_issues": "true"}}
+	assert.Equal(t, []string{"gh-1"}, getServiceInstanceIDList(f.apply(svcs)))
+
+	f = &serviceFilter{name: "secrets"}
+	assert.Equal(t, []string{"kp-1"}, getServiceInstanceIDList(f.apply(svcs)))
+
+	f = &serviceFilter{name: "docs"}
+	assert.Equal(t, []string{"ct-1"}, getServiceInstanceIDList(f.apply(svcs)))
+
+	f = &serviceFilter{serviceID: "keyprotect", name: "docs"}
+	assert.Empty(t, f.apply(svcs))
+}
+
+func TestFlattenIntegrations(t *testing.T) {
+	svcs := testToolchainServices()
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"integration_id": "gh-1",
+			"service_id":     "githubconsolidated",
+			"name":           "",
+			"parameters": map[string]interface{}{
+				"repo_url":   "https://github.com/org/app",
+				"has_issues": "true",
+			},
+		},
+		map[string]interface{}{
+			"integration_id": "kp-1",
+			"service_id":     "keyprotect",
+			"broker_id":      "broker",
+			"name":           "secrets",
+			"parameters": map[string]interface{}{
+				"name":   "secrets",
+				"region": "us-south",
+			},
+		},
+		map[string]interface{}{
+			"integration_id": "ct-1",
+			"service_id":     "customtool",
+			"name":           "docs",
+			"parameters": map[string]interface{}{
+				"instance_name": "docs",
+			},
+		},
+	}, flattenIntegrations([]oc.Service{svcs[0], svcs[2], svcs[3]}))
+}
@@ -45,12 +45,17 @@ func dataSourceOpenToolchainTektonPipeline() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"github_integration_id": {
-							Description: "Github integration ID",
+							Description: "Github or GitLab integration ID",
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
 						"github_url": {
-							Description: "Github repository URL",
+							Description: "Github or GitLab repository URL",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"scm_type": {
+							Description: "Repository integration type: `GitHub` or `GitLab`",
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
@@ -83,12 +88,17 @@ func dataSourceOpenToolchainTektonPipeline() *schema.Resource {
 							Computed:    true,
 						},
 						"github_integration_id": {
-							Description: "Github integration ID",
+							Description: "Github or GitLab integration ID",
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
 						"github_url": {
-							Description: "Github repository URL",
+							Description: "Github or GitLab repository URL",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"scm_type": {
+							Description: "Repository integration type: `GitHub` or `GitLab`",
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
@@ -0,0 +1,129 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+// classicPipelineType is reported for pipelines without `type` parameter, classic pipelines were created before tekton support
+const classicPipelineType = "classic"
+
+func dataSourceOpenToolchainTektonPipelines() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get toolchain pipelines, optionally filtered by name",
+		ReadContext: dataSourceOpenToolchainTektonPipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "Only return pipelines with this name",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"pipelines": {
+				Description: "Matching pipelines",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pipeline_id": {
+							Description: "The pipeline `guid`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Pipeline name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Pipeline type: `tekton` or `classic`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Pipeline status",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"dashboard_url": {
+							Description: "Pipeline dashboard URL",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpenToolchainTektonPipelinesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	toolchainID := d.Get("toolchain_id").(string)
+	envID := d.Get("env_id").(string)
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	svcs, err := getToolchainServices(ctx, c, toolchainID, region)
+
+	if err != nil {
+		return diag.Errorf("Error reading toolchain services: %s", err)
+	}
+
+	filter := &serviceFilter{
+		serviceID: pipelineServiceType,
+		name:      d.Get("name").(string),
+	}
+
+	if err := d.Set("pipelines", flattenToolchainPipelines(filter.apply(svcs))); err != nil {
+		return diag.Errorf("Error setting pipelines: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", toolchainID, envID))
+
+	return nil
+}
+
+func flattenToolchainPipelines(svcs []oc.Service) []interface{} {
+	result := make([]interface{}, 0, len(svcs))
+
+	for _, svc := range svcs {
+		pipeline := map[string]interface{}{
+			"pipeline_id": *svc.InstanceID,
+			"name":        getServiceName(svc.Parameters),
+			"type":        classicPipelineType,
+		}
+
+		if t, ok := svc.Parameters["type"].(string); ok && t != "" {
+			pipeline["type"] = t
+		}
+
+		if svc.Status != nil && svc.Status.State != nil {
+			pipeline["status"] = *svc.Status.State
+		}
+
+		if svc.DashboardURL != nil {
+			pipeline["dashboard_url"] = *svc.DashboardURL
+		}
+
+		result = append(result, pipeline)
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package opentoolchain
+
+import (
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlattenToolchainPipelines(t *testing.T) {
+	svcs := []oc.Service{
+		{
+			ServiceID:    getStringPtr(pipelineServiceType),
+			InstanceID:   getStringPtr("p-1"),
+			DashboardURL: getStringPtr("https://cloud.ibm.com/devops/pipelines/tekton/p-1"),
+			Status:       &oc.ServiceStatus{State: getStringPtr("configured")},
+			Parameters: map[string]interface{}{
+				"name": "ci",
+				"type": pipelineType,
+			},
+		},
+		{
+			ServiceID:  getStringPtr(pipelineServiceType),
+			InstanceID: getStringPtr("p-2"),
+			Parameters: map[string]interface{}{
+				"name": "legacy",
+			},
+		},
+		{
+			ServiceID:  getStringPtr(githubIntegrationServiceType),
+			InstanceID: getStringPtr("gh-1"),
+		},
+	}
+
+	filter := &serviceFilter{serviceID: pipelineServiceType}
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"pipeline_id":   "p-1",
+			"name":          "ci",
+			"type":          "tekton",
+			"status":        "configured",
+			"dashboard_url": "https://cloud.ibm.com/devops/pipelines/tekton/p-1",
+		},
+		map[string]interface{}{
+			"pipeline_id": "p-2",
+			"name":        "legacy",
+			"type":        "classic",
+		},
+	}, flattenToolchainPipelines(filter.apply(svcs)))
+
+	filter.name = "legacy"
+	assert.Len(t, flattenToolchainPipelines(filter.apply(svcs)), 1)
+}
@@ -127,10 +127,11 @@ func dataSourceOpenToolchainToolchain() *schema.Resource {
 				},
 				Computed: true,
 			},
-			// "lifecycle_messaging_webhook_id": {
-			// 	Type:     schema.TypeString,
-			// 	Computed: true,
-			// },
+			"lifecycle_messaging_webhook_id": {
+				Description: "Toolchain lifecycle messaging webhook ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -172,23 +173,13 @@ func dataSourceOpenToolchainToolchainRead(ctx context.Context, d *schema.Resourc
 		d.Set("template_repository", *toolchain.Template.URL)
 	}
 
-	listTagsOptions := &globaltaggingv1.ListTagsOptions{
-		AttachedTo: toolchain.CRN,
-	}
-
 	log.Printf("[DEBUG] Getting toolchain tags: %+v", toolchain)
-	tagList, _, err := t.ListTagsWithContext(ctx, listTagsOptions)
+	tags, err := getToolchainTags(ctx, t, *toolchain.CRN)
 
 	if err != nil {
 		return diag.Errorf("Error reading toolchain tags: %s", err)
 	}
 
-	var tags []string
-
-	for _, tag := range tagList.Items {
-		tags = append(tags, *tag.Name)
-	}
-
 	d.Set("services", flattenToolchainServices(toolchain.Services))
 	d.Set("tags", tags)
 
@@ -203,11 +194,32 @@ func dataSourceOpenToolchainToolchainRead(ctx context.Context, d *schema.Resourc
 	d.Set("url", fmt.Sprintf("%s?env_id=%s", u.String(), envID))
 	d.SetId(*toolchain.ToolchainGUID)
 
-	// d.Set("lifecycle_messaging_webhook_id", *toolchain.LifecycleMessagingWebhookID)
+	if toolchain.LifecycleMessagingWebhookID != nil {
+		d.Set("lifecycle_messaging_webhook_id", *toolchain.LifecycleMessagingWebhookID)
+	}
 
 	return nil
 }
 
+// getToolchainTags returns names of tags attached to toolchain
+func getToolchainTags(ctx context.Context, t *globaltaggingv1.GlobalTaggingV1, crn string) ([]string, error) {
+	tagList, _, err := t.ListTagsWithContext(ctx, &globaltaggingv1.ListTagsOptions{
+		AttachedTo: &crn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+
+	for _, tag := range tagList.Items {
+		tags = append(tags, *tag.Name)
+	}
+
+	return tags, nil
+}
+
 func flattenToolchainTemplate(tpl *oc.ToolchainTemplate) []interface{} {
 	if tpl == nil {
 		return []interface{}{}
@@ -0,0 +1,284 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceOpenToolchainToolchains() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get toolchains in a region, optionally filtered by name, resource group, tags or template repository (WARN: using undocumented APIs)",
+		ReadContext: dataSourceOpenToolchainToolchainsRead,
+		Schema: map[string]*schema.Schema{
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name_regex": {
+				Description:  "Only return toolchains with names matching this regular expression",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"resource_group_id": {
+				Description: "Only return toolchains in this resource group",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": {
+				Description: "Only return toolchains that have all of these tags, lookup fails if none of the toolchains in the region have them",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"template_repository": {
+				Description: "Only return toolchains created from this template repository",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"toolchains": {
+				Description: "Matching toolchains",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"guid": {
+							Description: "The toolchain `guid`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Toolchain name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"crn": {
+							Description: "Toolchain CRN",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"resource_group_id": {
+							Description: "Toolchain resource group ID",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"url": {
+							Description: "Toolchain URL",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"template_repository": {
+							Description: "The Git repository that the template was read from",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"tags": {
+							Description: "Toolchain tags",
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOpenToolchainToolchainsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	resourceGroupID := d.Get("resource_group_id").(string)
+	templateRepository := d.Get("template_repository").(string)
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	var nameRegex *regexp.Regexp
+
+	if r, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(r.(string))
+	}
+
+	requiredTags := expandStringList(d.Get("tags").(*schema.Set).List())
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	toolchains, err := listToolchains(ctx, c, region, resourceGroupID)
+
+	if err != nil {
+		return diag.Errorf("Error listing toolchains: %s", err)
+	}
+
+	// tags of all matching toolchains are read with a single search instead of a request per toolchain
+	tagsByCRN, err := searchToolchainTags(ctx, config.SearchClient, region, requiredTags)
+
+	if err != nil {
+		return diag.Errorf("Error searching toolchain tags: %s", err)
+	}
+
+	// search not matching any listed toolchain most likely means that query does not fit toolchain documents,
+	// fail instead of silently returning empty list
+	if len(requiredTags) > 0 && len(toolchains) > 0 && !containsAnyToolchainCRN(toolchains, tagsByCRN) {
+		return diag.Errorf("No toolchains with tags %s found in region %s", strings.Join(requiredTags, ", "), region)
+	}
+
+	result := make([]interface{}, 0)
+
+	for _, toolchain := range toolchains {
+		if toolchain.ToolchainGUID == nil {
+			continue
+		}
+
+		if nameRegex != nil && (toolchain.Name == nil || !nameRegex.MatchString(*toolchain.Name)) {
+			continue
+		}
+
+		// list endpoint may ignore resource group query parameter, so it is always checked here as well
+		if resourceGroupID != "" && getToolchainResourceGroupID(toolchain) != resourceGroupID {
+			continue
+		}
+
+		if templateRepository != "" && (toolchain.Template == nil || toolchain.Template.URL == nil ||
+			normalizeGitRepoURL(*toolchain.Template.URL) != normalizeGitRepoURL(templateRepository)) {
+			continue
+		}
+
+		var tags []string
+
+		if toolchain.CRN != nil {
+			tags = tagsByCRN[*toolchain.CRN]
+		}
+
+		if !containsAllStrings(tags, requiredTags) {
+			continue
+		}
+
+		result = append(result, flattenToolchainSummary(toolchain, envID, tags))
+	}
+
+	if err := d.Set("toolchains", result); err != nil {
+		return diag.Errorf("Error setting toolchains: %s", err)
+	}
+
+	d.SetId(envID)
+
+	return nil
+}
+
+// toolchainSearchPageSize is the number of results requested per page by searchToolchainTags
+const toolchainSearchPageSize = 1000
+
+// searchToolchainTags returns tags of toolchains in the region keyed by CRN, if required tags are specified
+// only toolchains having all of them are returned
+func searchToolchainTags(ctx context.Context, s *globalsearchv2.GlobalSearchV2, region string, requiredTags []string) (map[string][]string, error) {
+	limit := int64(toolchainSearchPageSize)
+
+	options := &globalsearchv2.SearchOptions{
+		Query:  getStringPtr(getToolchainSearchQuery(region, requiredTags)),
+		Fields: []string{"crn", "tags"},
+		Limit:  &limit,
+	}
+
+	result := make(map[string][]string)
+
+	for {
+		page, _, err := s.SearchWithContext(ctx, options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			if item.CRN == nil {
+				continue
+			}
+
+			tags, _ := item.GetProperty("tags").([]interface{})
+			result[*item.CRN] = expandStringList(tags)
+		}
+
+		// empty page signals the end of the result set
+		if len(page.Items) == 0 || page.SearchCursor == nil {
+			break
+		}
+
+		options.SearchCursor = page.SearchCursor
+	}
+
+	return result, nil
+}
+
+func containsAnyToolchainCRN(toolchains []oc.Toolchain, tagsByCRN map[string][]string) bool {
+	for _, toolchain := range toolchains {
+		if toolchain.CRN == nil {
+			continue
+		}
+
+		if _, ok := tagsByCRN[*toolchain.CRN]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getToolchainSearchQuery returns Global Search query matching toolchains in the region that have all required tags
+func getToolchainSearchQuery(region string, requiredTags []string) string {
+	clauses := []string{
+		"service_name:toolchain",
+		fmt.Sprintf("region:%s", region),
+	}
+
+	for _, tag := range requiredTags {
+		clauses = append(clauses, fmt.Sprintf("tags:%s", strconv.Quote(tag)))
+	}
+
+	return strings.Join(clauses, " AND ")
+}
+
+// getToolchainResourceGroupID returns toolchain resource group ID, toolchains in Cloud Foundry orgs have none
+func getToolchainResourceGroupID(toolchain oc.Toolchain) string {
+	if toolchain.Container != nil && toolchain.Container.GUID != nil && toolchain.Container.Type != nil && *toolchain.Container.Type == "resource_group_id" {
+		return *toolchain.Container.GUID
+	}
+
+	return ""
+}
+
+func flattenToolchainSummary(toolchain oc.Toolchain, envID string, tags []string) map[string]interface{} {
+	result := map[string]interface{}{
+		"guid":              *toolchain.ToolchainGUID,
+		"url":               fmt.Sprintf("https://cloud.ibm.com/devops/toolchains/%s?env_id=%s", *toolchain.ToolchainGUID, envID),
+		"resource_group_id": getToolchainResourceGroupID(toolchain),
+		"tags":              tags,
+	}
+
+	if toolchain.Name != nil {
+		result["name"] = *toolchain.Name
+	}
+
+	if toolchain.CRN != nil {
+		result["crn"] = *toolchain.CRN
+	}
+
+	if toolchain.Template != nil && toolchain.Template.URL != nil {
+		result["template_repository"] = *toolchain.Template.URL
+	}
+
+	return result
+}
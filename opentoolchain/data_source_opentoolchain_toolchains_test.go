@@ -0,0 +1,164 @@
+package opentoolchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestListToolchainsPaging(t *testing.T) {
+	total := toolchainListPageSize + 5
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/devops-api.us-south.devops.cloud.ibm.com/v1/toolchains", r.URL.Path)
+		assert.Equal(t, "rg-1", r.URL.Query().Get("resource_group_id"))
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var items []interface{}
+
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, map[string]interface{}{
+				"toolchain_guid": fmt.Sprintf("tc-%d", i),
+				"name":           fmt.Sprintf("toolchain %d", i),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_results": total,
+			"items":         items,
+		})
+	}))
+	defer server.Close()
+
+	c, err := oc.NewOpenToolchainV1(&oc.OpenToolchainV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+
+	assert.NoError(t, err)
+
+	toolchains, err := listToolchains(context.Background(), c, "us-south", "rg-1")
+
+	assert.NoError(t, err)
+	assert.Len(t, toolchains, total)
+	assert.Equal(t, fmt.Sprintf("tc-%d", total-1), *toolchains[total-1].ToolchainGUID)
+}
+
+func TestFlattenToolchainSummary(t *testing.T) {
+	toolchain := oc.Toolchain{
+		ToolchainGUID: getStringPtr("tc-1"),
+		Name:          getStringPtr("app"),
+		CRN:           getStringPtr("crn:v1:bluemix:public:toolchain:us-south:a/1::toolchain:tc-1"),
+		Container: &oc.Container{
+			GUID: getStringPtr("rg-1"),
+			Type: getStringPtr("resource_group_id"),
+		},
+		Template: &oc.ToolchainTemplate{
+			URL: getStringPtr("https://github.com/open-toolchain/empty-toolchain"),
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"guid":                "tc-1",
+		"name":                "app",
+		"crn":                 "crn:v1:bluemix:public:toolchain:us-south:a/1::toolchain:tc-1",
+		"url":                 "https://cloud.ibm.com/devops/toolchains/tc-1?env_id=ibm:yp:us-south",
+		"resource_group_id":   "rg-1",
+		"template_repository": "https://github.com/open-toolchain/empty-toolchain",
+		"tags":                []string{"team:a"},
+	}, flattenToolchainSummary(toolchain, "ibm:yp:us-south", []string{"team:a"}))
+
+	// toolchains in Cloud Foundry orgs do not belong to resource group
+	toolchain.Container.Type = getStringPtr("organization_guid")
+	assert.Equal(t, "", getToolchainResourceGroupID(toolchain))
+}
+
+func TestContainsAllStrings(t *testing.T) {
+	assert.True(t, containsAllStrings([]string{"a", "b"}, nil))
+	assert.True(t, containsAllStrings([]string{"a", "b"}, []string{"b", "a"}))
+	assert.False(t, containsAllStrings([]string{"a"}, []string{"a", "b"}))
+	assert.False(t, containsAllStrings(nil, []string{"a"}))
+}
+
+func TestSearchToolchainTags(t *testing.T) {
+	pages := [][]interface{}{
+		{
+			map[string]interface{}{"crn": "crn:tc-1", "tags": []interface{}{"env:dev", "team"}},
+			map[string]interface{}{"crn": "crn:tc-2", "tags": []interface{}{"env:dev"}},
+		},
+		{
+			map[string]interface{}{"crn": "crn:tc-3", "tags": []interface{}{}},
+		},
+		{},
+	}
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/resources/search", r.URL.Path)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, `service_name:toolchain AND region:us-south AND tags:"env:dev"`, body["query"])
+
+		if requests == 0 {
+			assert.Nil(t, body["search_cursor"])
+		} else {
+			assert.Equal(t, fmt.Sprintf("cursor-%d", requests), body["search_cursor"])
+		}
+
+		items := pages[requests]
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"search_cursor": fmt.Sprintf("cursor-%d", requests),
+			"items":         items,
+		})
+	}))
+	defer server.Close()
+
+	s, err := globalsearchv2.NewGlobalSearchV2(&globalsearchv2.GlobalSearchV2Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+
+	assert.NoError(t, err)
+
+	tags, err := searchToolchainTags(context.Background(), s, "us-south", []string{"env:dev"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(pages), requests)
+	assert.Equal(t, map[string][]string{
+		"crn:tc-1": {"env:dev", "team"},
+		"crn:tc-2": {"env:dev"},
+		"crn:tc-3": {},
+	}, tags)
+}
+
+func TestGetToolchainSearchQuery(t *testing.T) {
+	assert.Equal(t, "service_name:toolchain AND region:us-south", getToolchainSearchQuery("us-south", nil))
+	assert.Equal(t, `service_name:toolchain AND region:eu-de AND tags:"env:prod" AND tags:"team \"a\""`, getToolchainSearchQuery("eu-de", []string{"env:prod", `team "a"`}))
+}
+
+func TestContainsAnyToolchainCRN(t *testing.T) {
+	toolchains := []oc.Toolchain{
+		{CRN: getStringPtr("crn:tc-1")},
+		{},
+	}
+
+	assert.True(t, containsAnyToolchainCRN(toolchains, map[string][]string{"crn:tc-1": {"env:dev"}}))
+	assert.False(t, containsAnyToolchainCRN(toolchains, map[string][]string{"crn:other": {"env:dev"}}))
+	assert.False(t, containsAnyToolchainCRN(toolchains, map[string][]string{}))
+}
@@ -0,0 +1,167 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attributes that must be set for each git integration `repo_type`
+var gitRepoTypeRequiredAttrs = map[string][]string{
+	"link":     {"repo_url"},
+	"existing": {"repo_url"},
+	"clone":    {"source_repo_url", "repo_name"},
+	"fork":     {"source_repo_url", "repo_name"},
+	"new":      {"repo_name"},
+}
+
+// repository URLs returned by the API usually end with `.git`, GitHub Enterprise and
+// self-managed GitLab servers may also return hostnames in different case
+func suppressGitSuffixDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeGitRepoURL(old) == normalizeGitRepoURL(new)
+}
+
+// normalizeGitRepoURL lowercases scheme and host, removes trailing `/` and `.git` from repository path
+func normalizeGitRepoURL(repoURL string) string {
+	u, err := url.Parse(repoURL)
+
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), ".git")
+	u.RawPath = ""
+
+	return u.String()
+}
+
+// server URL is sent without trailing `/`, and may be returned with hostname in different case
+func suppressGitServerURLDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeGitServerURL(old) == normalizeGitServerURL(new)
+}
+
+// normalizeGitServerURL lowercases scheme and host, removes trailing `/`
+func normalizeGitServerURL(serverURL string) string {
+	u, err := url.Parse(serverURL)
+
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(serverURL, "/")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String()
+}
+
+// getGitServerParams returns service instance parameters that identify git server, public server (github.com, gitlab.com)
+// is built-in, enterprise and self-managed servers are registered with the integration using `<gitID>custom` git ID
+func getGitServerParams(gitID string, defaultServerURL string, apiPath string, serverURL string) (map[string]interface{}, error) {
+	serverURL = normalizeGitServerURL(serverURL)
+
+	if serverURL == defaultServerURL {
+		return map[string]interface{}{
+			"git_id":     gitID,
+			"authorized": gitID,
+		}, nil
+	}
+
+	u, err := url.Parse(serverURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host in %s", serverURL)
+	}
+
+	customID := fmt.Sprintf("%scustom", gitID)
+
+	return map[string]interface{}{
+		"git_id":       customID,
+		"authorized":   customID,
+		"title":        u.Host,
+		"root_url":     serverURL,
+		"api_root_url": serverURL + apiPath,
+	}, nil
+}
+
+// flattenGitIntegrationParams maps service instance parameters to git integration attributes
+func flattenGitIntegrationParams(gitID string, defaultServerURL string, params map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	if g, ok := params["git_id"].(string); ok {
+		if g == gitID {
+			result["server_url"] = defaultServerURL
+		} else if r, ok := params["root_url"].(string); ok {
+			result["server_url"] = r
+		}
+	}
+
+	for param, attr := range map[string]string{
+		"type":            "repo_type",
+		"repo_url":        "repo_url",
+		"source_repo_url": "source_repo_url",
+		"repo_name":       "repo_name",
+		"owner_id":        "owner_id",
+		"auth_type":       "auth_type",
+	} {
+		if v, ok := params[param].(string); ok && v != "" {
+			result[attr] = v
+		}
+	}
+
+	for param, attr := range map[string]string{
+		"private_repo":        "private",
+		"has_issues":          "enable_issues",
+		"enable_traceability": "enable_traceability",
+	} {
+		if v, ok := params[param].(bool); ok {
+			result[attr] = v
+		}
+	}
+
+	return result
+}
+
+// customizeGitRepoTypeDiff checks that attributes required by selected `repo_type` are set
+func customizeGitRepoTypeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("repo_type") {
+		return nil
+	}
+
+	repoType := d.Get("repo_type").(string)
+
+	for _, attr := range gitRepoTypeRequiredAttrs[repoType] {
+		if !d.NewValueKnown(attr) {
+			continue
+		}
+
+		if d.Get(attr).(string) == "" {
+			return fmt.Errorf("%s is required when repo_type is %s", attr, repoType)
+		}
+	}
+
+	return nil
+}
+
+// customizeGitAuthDiff checks that `api_token` is set for personal access token authentication
+func customizeGitAuthDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("auth_type") || !d.NewValueKnown("api_token") {
+		return nil
+	}
+
+	if d.Get("auth_type").(string) == "pat" && d.Get("api_token").(string) == "" {
+		return fmt.Errorf("api_token is required when auth_type is pat")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetGitServerParams(t *testing.T) {
+	params, err := getGitServerParams(gitlabGitID, gitlabDefaultServerURL, "/api/v4", "https://gitlab.com/")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"git_id":     "gitlab",
+		"authorized": "gitlab",
+	}, params)
+
+	params, err = getGitServerParams(gitlabGitID, gitlabDefaultServerURL, "/api/v4", "https://gitlab.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"git_id":       "gitlabcustom",
+		"authorized":   "gitlabcustom",
+		"title":        "gitlab.example.com",
+		"root_url":     "https://gitlab.example.com",
+		"api_root_url": "https://gitlab.example.com/api/v4",
+	}, params)
+
+	params, err = getGitServerParams(githubGitID, githubDefaultServerURL, "/api/v3", "https://GitHub.Example.com/")
+	assert.NoError(t, err)
+	assert.Equal(t, "githubcustom", params["git_id"])
+	assert.Equal(t, "https://github.example.com", params["root_url"])
+	assert.Equal(t, "https://github.example.com/api/v3", params["api_root_url"])
+
+	_, err = getGitServerParams(gitlabGitID, gitlabDefaultServerURL, "/api/v4", "gitlab")
+	assert.Error(t, err)
+}
+
+func TestFlattenGitIntegrationParams(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"server_url":          "https://gitlab.example.com",
+		"repo_type":           "link",
+		"repo_url":            "https://gitlab.example.com/group/repo.git",
+		"private":             true,
+		"enable_issues":       false,
+		"enable_traceability": true,
+	}, flattenGitIntegrationParams(gitlabGitID, gitlabDefaultServerURL, map[string]interface{}{
+		"git_id":              "gitlabcustom",
+		"root_url":            "https://gitlab.example.com",
+		"type":                "link",
+		"repo_url":            "https://gitlab.example.com/group/repo.git",
+		"source_repo_url":     "",
+		"private_repo":        true,
+		"has_issues":          false,
+		"enable_traceability": true,
+	}))
+
+	assert.Equal(t, "https://github.com", flattenGitIntegrationParams(githubGitID, githubDefaultServerURL, map[string]interface{}{
+		"git_id": "github",
+	})["server_url"])
+}
+
+func TestSuppressGitSuffixDiff(t *testing.T) {
+	for _, urls := range [][]string{
+		{"https://github.com/org/repo", "https://github.com/org/repo.git"},
+		{"https://GitHub.Example.com/org/repo.git", "https://github.example.com/org/repo"},
+		{"https://git.example.com/org/repo/", "https://git.example.com/org/repo"},
+	} {
+		assert.True(t, suppressGitSuffixDiff("repo_url", urls[0], urls[1], nil), urls)
+	}
+
+	for _, urls := range [][]string{
+		{"https://github.com/org/repo", "https://github.com/org/other"},
+		{"https://github.com/org/repo", "https://github.example.com/org/repo"},
+		// repository path is case sensitive
+		{"https://github.com/org/Repo", "https://github.com/org/repo"},
+		// .git is only trimmed from repository path, not from the hostname
+		{"https://example.git/org/repo", "https://example/org/repo"},
+	} {
+		assert.False(t, suppressGitSuffixDiff("repo_url", urls[0], urls[1], nil), urls)
+	}
+}
+
+func TestSuppressGitServerURLDiff(t *testing.T) {
+	for _, urls := range [][]string{
+		{"https://ghe.example.com/", "https://ghe.example.com"},
+		{"HTTPS://GHE.Example.com", "https://ghe.example.com"},
+		{"https://git.example.com/gitlab/", "https://git.example.com/gitlab"},
+	} {
+		assert.True(t, suppressGitServerURLDiff("server_url", urls[0], urls[1], nil), urls)
+	}
+
+	for _, urls := range [][]string{
+		{"https://ghe.example.com", "https://github.com"},
+		{"https://git.example.com/gitlab", "https://git.example.com"},
+	} {
+		assert.False(t, suppressGitServerURLDiff("server_url", urls[0], urls[1], nil), urls)
+	}
+}
@@ -14,6 +14,14 @@ func getBoolPtr(b bool) *bool {
 	return &val
 }
 
+// returns empty string for nil pointers
+func getStringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 func dbgPrint(data interface{}) string {
 	dataJSON, _ := json.MarshalIndent(data, "", "  ")
 	return string(dataJSON)
@@ -30,6 +38,23 @@ func expandStringList(list []interface{}) []string {
 	return vs
 }
 
+// containsAllStrings returns true if every string in subset is present in list
+func containsAllStrings(list []string, subset []string) bool {
+	values := make(map[string]bool)
+
+	for _, v := range list {
+		values[v] = true
+	}
+
+	for _, v := range subset {
+		if !values[v] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // compares source map keys or array of strings against target map keys
 // returns a list of matched keys and new keys
 func getKeyDiff(targetMap map[string]interface{}, source interface{}) (matchedKeys, newKeys []interface{}) {
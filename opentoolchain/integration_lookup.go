@@ -0,0 +1,116 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sort"
+	"strings"
+)
+
+// integrationLookup maps integration data source attribute to service instance parameter,
+// lookup attributes can be used instead of `integration_id` to find the integration
+type integrationLookup struct {
+	attr  string
+	param string
+	// optional value normalization, for example to ignore `.git` suffix in repository URLs
+	normalize func(string) string
+}
+
+var gitRepoURLLookup = []integrationLookup{
+	{attr: "repo_url", param: "repo_url", normalize: normalizeGitRepoURL},
+}
+
+// setIntegrationLookupSchema makes `integration_id` and lookup attributes optional, lookup attributes
+// must already be defined in data source schema
+func setIntegrationLookupSchema(s map[string]*schema.Schema, lookups []integrationLookup) {
+	var attrs []string
+
+	for _, l := range lookups {
+		attrs = append(attrs, l.attr)
+	}
+
+	description := "The integration `guid`, if not set, the only integration of this type in the toolchain is used"
+
+	if len(attrs) > 0 {
+		description = fmt.Sprintf("The integration `guid`, if not set, the only integration matching `%s` is used", strings.Join(attrs, "`, `"))
+	}
+
+	s["integration_id"] = &schema.Schema{
+		Description:   description,
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: attrs,
+	}
+
+	for _, attr := range attrs {
+		s[attr].Optional = true
+		s[attr].Computed = true
+		s[attr].ConflictsWith = []string{"integration_id"}
+	}
+}
+
+// getDataSourceIntegrationID returns `integration_id` if it is set, otherwise looks up toolchain integrations
+// of given service type matching lookup attributes, exactly one integration must match
+func getDataSourceIntegrationID(ctx context.Context, c *oc.OpenToolchainV1, d *schema.ResourceData, serviceID string, lookups []integrationLookup) (string, error) {
+	if id, ok := d.GetOk("integration_id"); ok {
+		return id.(string), nil
+	}
+
+	toolchainID := d.Get("toolchain_id").(string)
+	envID := d.Get("env_id").(string)
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	filter := &serviceFilter{
+		serviceID:  serviceID,
+		parameters: make(map[string]interface{}),
+		normalize:  make(map[string]func(string) string),
+	}
+
+	var criteria []string
+
+	for _, l := range lookups {
+		if v, ok := d.GetOk(l.attr); ok {
+			filter.parameters[l.param] = v.(string)
+			criteria = append(criteria, fmt.Sprintf("%s = %q", l.attr, v.(string)))
+
+			if l.normalize != nil {
+				filter.normalize[l.param] = l.normalize
+			}
+		}
+	}
+
+	svcs, err := getToolchainServices(ctx, c, toolchainID, region)
+
+	if err != nil {
+		return "", err
+	}
+
+	matches := filter.apply(svcs)
+
+	description := fmt.Sprintf("%s integrations", serviceID)
+
+	if len(criteria) > 0 {
+		sort.Strings(criteria)
+		description = fmt.Sprintf("%s integrations with %s", serviceID, strings.Join(criteria, ", "))
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s found in toolchain %s", description, toolchainID)
+	case 1:
+		return *matches[0].InstanceID, nil
+	default:
+		var ids []string
+
+		for _, svc := range matches {
+			ids = append(ids, *svc.InstanceID)
+		}
+
+		return "", fmt.Errorf("found %d %s in toolchain %s (%s), use `integration_id` instead", len(matches), description, toolchainID, strings.Join(ids, ", "))
+	}
+}
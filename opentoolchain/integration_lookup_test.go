@@ -0,0 +1,44 @@
+package opentoolchain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetIntegrationLookupSchema(t *testing.T) {
+	s := dataSourceOpenToolchainIntegrationGithub().Schema
+
+	assert.True(t, s["integration_id"].Optional)
+	assert.True(t, s["integration_id"].Computed)
+	assert.Equal(t, []string{"repo_url"}, s["integration_id"].ConflictsWith)
+	assert.True(t, s["repo_url"].Optional)
+	assert.Equal(t, []string{"integration_id"}, s["repo_url"].ConflictsWith)
+
+	// integrations without lookup attributes can still be found if there is only one per toolchain
+	s = dataSourceOpenToolchainIntegrationDevOpsInsights().Schema
+
+	assert.True(t, s["integration_id"].Optional)
+	assert.Empty(t, s["integration_id"].ConflictsWith)
+}
+
+func TestGetDataSourceIntegrationIDExplicit(t *testing.T) {
+	d := dataSourceOpenToolchainIntegrationGithub().TestResourceData()
+	d.Set("integration_id", "gh-1")
+
+	// client is not used when integration_id is set
+	id, err := getDataSourceIntegrationID(context.Background(), nil, d, githubIntegrationServiceType, gitRepoURLLookup)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "gh-1", id)
+}
+
+func TestServiceFilterNormalize(t *testing.T) {
+	f := &serviceFilter{
+		serviceID:  "githubconsolidated",
+		parameters: map[string]interface{}{"repo_url": "https://GitHub.com/org/app/"},
+		normalize:  map[string]func(string) string{"repo_url": normalizeGitRepoURL},
+	}
+
+	assert.Equal(t, []string{"gh-1"}, getServiceInstanceIDList(f.apply(testToolchainServices())))
+}
@@ -0,0 +1,123 @@
+package opentoolchain
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// notificationEvents lists toolchain events shared by notification integrations (slack, msteams, webhook),
+// each event maps to a boolean service instance parameter with the same name
+var notificationEvents = []string{
+	"pipeline_start",
+	"pipeline_success",
+	"pipeline_fail",
+	"toolchain_bind",
+	"toolchain_unbind",
+}
+
+// optionalNotificationEvents are disabled by default, older integrations do not have these parameters at all
+var optionalNotificationEvents = []string{
+	"pipeline_task_fail",
+	"toolchain_update",
+}
+
+func notificationEventsSchema(tool string) *schema.Schema {
+	return &schema.Schema{
+		Description: "Events for which you want to receive notifications",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pipeline_start": {
+					Description: fmt.Sprintf("Send %s notification when pipeline is started", tool),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"pipeline_success": {
+					Description: fmt.Sprintf("Send %s notification when pipeline succeeds", tool),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"pipeline_fail": {
+					Description: fmt.Sprintf("Send %s notification when pipeline fails", tool),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"toolchain_bind": {
+					Description: fmt.Sprintf("Send %s notification when integration is created", tool),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"toolchain_unbind": {
+					Description: fmt.Sprintf("Send %s notification when integration is removed", tool),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+				},
+				"pipeline_task_fail": {
+					Description: fmt.Sprintf("Send %s notification when pipeline task fails", tool),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"toolchain_update": {
+					Description: fmt.Sprintf("Send %s notification when toolchain is updated", tool),
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+			},
+		},
+	}
+}
+
+// expandNotificationEvents adds event parameters, all events are enabled if `events` block is not set
+func expandNotificationEvents(d *schema.ResourceData, params map[string]interface{}) {
+	var e map[string]interface{}
+
+	if evt, ok := d.GetOk("events"); ok && len(evt.([]interface{})) > 0 && evt.([]interface{})[0] != nil {
+		e = evt.([]interface{})[0].(map[string]interface{})
+	}
+
+	for _, k := range notificationEvents {
+		if v, ok := e[k].(bool); ok {
+			params[k] = v
+		} else {
+			params[k] = true
+		}
+	}
+
+	for _, k := range optionalNotificationEvents {
+		v, _ := e[k].(bool)
+		params[k] = v
+	}
+}
+
+// flattenNotificationEvents sets `events` block if all default event parameters are present,
+// missing optional events are treated as disabled
+func flattenNotificationEvents(params map[string]interface{}, result map[string]interface{}) {
+	events := make(map[string]interface{})
+
+	for _, k := range notificationEvents {
+		v, ok := params[k].(bool)
+
+		if !ok {
+			return
+		}
+
+		events[k] = v
+	}
+
+	for _, k := range optionalNotificationEvents {
+		v, _ := params[k].(bool)
+		events[k] = v
+	}
+
+	result["events"] = []interface{}{events}
+}
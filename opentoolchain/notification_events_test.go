@@ -0,0 +1,70 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExpandNotificationEvents(t *testing.T) {
+	d := resourceOpenToolchainIntegrationMSTeams().TestResourceData()
+	params := make(map[string]interface{})
+
+	expandNotificationEvents(d, params)
+
+	assert.Equal(t, map[string]interface{}{
+		"pipeline_start":     true,
+		"pipeline_success":   true,
+		"pipeline_fail":      true,
+		"toolchain_bind":     true,
+		"toolchain_unbind":   true,
+		"pipeline_task_fail": false,
+		"toolchain_update":   false,
+	}, params)
+
+	d.Set("events", []interface{}{map[string]interface{}{
+		"pipeline_start":     false,
+		"pipeline_success":   true,
+		"pipeline_fail":      true,
+		"toolchain_bind":     false,
+		"toolchain_unbind":   false,
+		"pipeline_task_fail": true,
+	}})
+
+	expandNotificationEvents(d, params)
+
+	assert.Equal(t, false, params["pipeline_start"])
+	assert.Equal(t, true, params["pipeline_fail"])
+	assert.Equal(t, false, params["toolchain_unbind"])
+	assert.Equal(t, true, params["pipeline_task_fail"])
+	assert.Equal(t, false, params["toolchain_update"])
+}
+
+func TestFlattenNotificationEvents(t *testing.T) {
+	params := map[string]interface{}{
+		"name":             "teams",
+		"api_token":        "encrypted",
+		"pipeline_start":   true,
+		"pipeline_success": false,
+		"pipeline_fail":    true,
+		"toolchain_bind":   false,
+		"toolchain_unbind": false,
+	}
+
+	expected := map[string]interface{}{
+		"name": "teams",
+		"events": []interface{}{map[string]interface{}{
+			"pipeline_start":     true,
+			"pipeline_success":   false,
+			"pipeline_fail":      true,
+			"toolchain_bind":     false,
+			"toolchain_unbind":   false,
+			"pipeline_task_fail": false,
+			"toolchain_update":   false,
+		}},
+	}
+
+	assert.Equal(t, expected, msteamsIntegration.flattenParams(params))
+
+	delete(params, "pipeline_start")
+	assert.NotContains(t, webhookIntegration.flattenParams(params), "events")
+}
@@ -0,0 +1,204 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+)
+
+// patchTektonPipeline runs read-modify-write cycle against a single pipeline while holding per-pipeline lock,
+// several resources can target the same pipeline and PATCH always replaces whole property and trigger lists.
+// makePatch receives freshly read pipeline and returns patch options (GUID and Region are set here),
+// returning nil options skips the PATCH. Once patched, the result is verified to make sure that
+// only properties and triggers touched by this patch were changed. The lock only serializes writes made
+// by this provider process, changes made by other clients are reported by the verification, not prevented.
+func patchTektonPipeline(ctx context.Context, config *ProviderConfig, guid string, region string, makePatch func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error)) (*oc.TektonPipeline, error) {
+	config.PipelineLocks.Lock(guid)
+	defer config.PipelineLocks.Unlock(guid)
+
+	c := config.OTClient
+
+	pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
+		GUID:   &guid,
+		Region: &region,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading tekton pipeline: %s", err)
+	}
+
+	patchOptions, err := makePatch(pipeline)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if patchOptions == nil {
+		return pipeline, nil
+	}
+
+	patchOptions.GUID = &guid
+	patchOptions.Region = &region
+
+	patchedPipeline, _, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if patchedPipeline != nil {
+		if err := verifyTektonPipelinePatch(pipeline, patchOptions, patchedPipeline); err != nil {
+			return patchedPipeline, err
+		}
+	}
+
+	return patchedPipeline, nil
+}
+
+// verifyTektonPipelinePatch makes sure that any difference between pipeline state before and after
+// the patch is explained by the patch itself, anything else means pipeline was modified concurrently
+func verifyTektonPipelinePatch(before *oc.TektonPipeline, patch *oc.PatchTektonPipelineOptions, after *oc.TektonPipeline) error {
+	var unexpected []string
+
+	requestedEnv := make(map[string]bool)
+
+	if patch.EnvProperties != nil {
+		for _, k := range diffEnvProperties(before.EnvProperties, patch.EnvProperties) {
+			requestedEnv[k] = true
+		}
+	}
+
+	for _, k := range diffEnvProperties(before.EnvProperties, after.EnvProperties) {
+		if !requestedEnv[k] {
+			unexpected = append(unexpected, fmt.Sprintf("property '%s'", k))
+		}
+	}
+
+	requestedTriggers := make(map[string]bool)
+
+	if patch.Triggers != nil {
+		for _, k := range diffTektonPipelineTriggers(before.Triggers, patch.Triggers) {
+			requestedTriggers[k] = true
+		}
+	}
+
+	for _, k := range diffTektonPipelineTriggers(before.Triggers, after.Triggers) {
+		if !requestedTriggers[k] {
+			unexpected = append(unexpected, fmt.Sprintf("trigger '%s'", k))
+		}
+	}
+
+	if len(unexpected) > 0 {
+		return fmt.Errorf("tekton pipeline %s was modified concurrently, unexpected changes to %s, please re-apply", *before.ID, strings.Join(unexpected, ", "))
+	}
+
+	return nil
+}
+
+// returns sorted names of properties that were added, removed or modified,
+// secure values are encrypted by the API so only their presence is compared
+func diffEnvProperties(a []oc.EnvProperty, b []oc.EnvProperty) []string {
+	aMap := make(map[string]oc.EnvProperty)
+	bMap := make(map[string]oc.EnvProperty)
+
+	for _, p := range a {
+		aMap[*p.Name] = p
+	}
+
+	for _, p := range b {
+		bMap[*p.Name] = p
+	}
+
+	var result []string
+
+	for k, ap := range aMap {
+		bp, ok := bMap[k]
+
+		if !ok || getStringValue(ap.Type) != getStringValue(bp.Type) {
+			result = append(result, k)
+			continue
+		}
+
+		if getStringValue(ap.Type) != "SECURE" && getStringValue(ap.Value) != getStringValue(bp.Value) {
+			result = append(result, k)
+		}
+	}
+
+	for k := range bMap {
+		if _, ok := aMap[k]; !ok {
+			result = append(result, k)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// returns sorted IDs of triggers that were added, removed or modified
+func diffTektonPipelineTriggers(a []oc.TektonPipelineTrigger, b []oc.TektonPipelineTrigger) []string {
+	aMap := make(map[string]oc.TektonPipelineTrigger)
+	bMap := make(map[string]oc.TektonPipelineTrigger)
+
+	for _, t := range a {
+		aMap[getStringValue(t.ID)] = t
+	}
+
+	for _, t := range b {
+		bMap[getStringValue(t.ID)] = t
+	}
+
+	var result []string
+
+	for k, at := range aMap {
+		bt, ok := bMap[k]
+
+		if !ok || tektonPipelineTriggerSignature(at) != tektonPipelineTriggerSignature(bt) {
+			result = append(result, k)
+		}
+	}
+
+	for k := range bMap {
+		if _, ok := aMap[k]; !ok {
+			result = append(result, k)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// flattens user modifiable trigger settings into a comparable string, nil and empty values are treated the same
+func tektonPipelineTriggerSignature(t oc.TektonPipelineTrigger) string {
+	fields := []string{
+		getStringValue(t.Name),
+		getStringValue(t.Type),
+		getStringValue(t.EventListener),
+		getStringValue(t.ServiceInstanceID),
+		fmt.Sprintf("%t", t.Disabled != nil && *t.Disabled),
+	}
+
+	scm := oc.TektonPipelineTriggerScmSource{}
+	events := oc.TektonPipelineTriggerEvents{}
+
+	if t.ScmSource != nil {
+		scm = *t.ScmSource
+	}
+
+	if t.Events != nil {
+		events = *t.Events
+	}
+
+	fields = append(fields,
+		getStringValue(scm.URL),
+		getStringValue(scm.Branch),
+		getStringValue(scm.Pattern),
+		fmt.Sprintf("%t", events.Push != nil && *events.Push),
+		fmt.Sprintf("%t", events.PullRequest != nil && *events.PullRequest),
+		fmt.Sprintf("%t", events.PullRequestClosed != nil && *events.PullRequestClosed),
+	)
+
+	return strings.Join(fields, "|")
+}
@@ -0,0 +1,161 @@
+package opentoolchain
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/go-sdk-core/v5/core"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyTektonPipelinePatch(t *testing.T) {
+	before := &oc.TektonPipeline{
+		ID: getStringPtr("pipeline"),
+		EnvProperties: []oc.EnvProperty{
+			{Name: getStringPtr("OURS"), Value: getStringPtr("old"), Type: getStringPtr("TEXT")},
+			{Name: getStringPtr("THEIRS"), Value: getStringPtr("value"), Type: getStringPtr("TEXT")},
+			{Name: getStringPtr("SECRET"), Value: getStringPtr("encrypted"), Type: getStringPtr("SECURE")},
+		},
+		Triggers: []oc.TektonPipelineTrigger{
+			{ID: getStringPtr("1"), Name: getStringPtr("manual"), Type: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+		},
+	}
+
+	testcases := []struct {
+		patch       *oc.PatchTektonPipelineOptions
+		after       *oc.TektonPipeline
+		expectedErr bool
+	}{
+		{
+			// only patched property changed, secret was re-encrypted
+			patch: &oc.PatchTektonPipelineOptions{
+				EnvProperties: []oc.EnvProperty{
+					{Name: getStringPtr("OURS"), Value: getStringPtr("new"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("THEIRS"), Value: getStringPtr("value"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("SECRET"), Value: getStringPtr("encrypted"), Type: getStringPtr("SECURE")},
+				},
+			},
+			after: &oc.TektonPipeline{
+				EnvProperties: []oc.EnvProperty{
+					{Name: getStringPtr("OURS"), Value: getStringPtr("new"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("THEIRS"), Value: getStringPtr("value"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("SECRET"), Value: getStringPtr("re-encrypted"), Type: getStringPtr("SECURE")},
+				},
+				Triggers: before.Triggers,
+			},
+			expectedErr: false,
+		},
+		{
+			// property that was not part of the patch changed
+			patch: &oc.PatchTektonPipelineOptions{
+				EnvProperties: []oc.EnvProperty{
+					{Name: getStringPtr("OURS"), Value: getStringPtr("new"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("THEIRS"), Value: getStringPtr("value"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("SECRET"), Value: getStringPtr("encrypted"), Type: getStringPtr("SECURE")},
+				},
+			},
+			after: &oc.TektonPipeline{
+				EnvProperties: []oc.EnvProperty{
+					{Name: getStringPtr("OURS"), Value: getStringPtr("new"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("THEIRS"), Value: getStringPtr("changed"), Type: getStringPtr("TEXT")},
+					{Name: getStringPtr("SECRET"), Value: getStringPtr("encrypted"), Type: getStringPtr("SECURE")},
+				},
+				Triggers: before.Triggers,
+			},
+			expectedErr: true,
+		},
+		{
+			// new trigger added by the patch, nil and empty scm values are equivalent
+			patch: &oc.PatchTektonPipelineOptions{
+				Triggers: []oc.TektonPipelineTrigger{
+					{ID: getStringPtr("1"), Name: getStringPtr("manual"), Type: getStringPtr("manual"), Disabled: getBoolPtr(false), ScmSource: &oc.TektonPipelineTriggerScmSource{Pattern: getStringPtr("")}},
+					{ID: getStringPtr("2"), Name: getStringPtr("feature"), Type: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+				},
+			},
+			after: &oc.TektonPipeline{
+				EnvProperties: before.EnvProperties,
+				Triggers: []oc.TektonPipelineTrigger{
+					{ID: getStringPtr("1"), Name: getStringPtr("manual"), Type: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+					{ID: getStringPtr("2"), Name: getStringPtr("feature"), Type: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			// trigger was removed although patch did not include triggers
+			patch: &oc.PatchTektonPipelineOptions{},
+			after: &oc.TektonPipeline{
+				EnvProperties: before.EnvProperties,
+				Triggers:      []oc.TektonPipelineTrigger{},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range testcases {
+		err := verifyTektonPipelinePatch(before, c.patch, c.after)
+
+		if c.expectedErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestPatchTektonPipelineConcurrentChange(t *testing.T) {
+	gets, patches := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		properties := []interface{}{
+			map[string]interface{}{"name": "OURS", "value": "old", "type": "TEXT"},
+			map[string]interface{}{"name": "THEIRS", "value": "value", "type": "TEXT"},
+		}
+
+		if r.Method == http.MethodGet {
+			gets++
+		} else {
+			// someone else changed THEIRS while our patch was in flight
+			patches++
+			properties = []interface{}{
+				map[string]interface{}{"name": "OURS", "value": "new", "type": "TEXT"},
+				map[string]interface{}{"name": "THEIRS", "value": "changed", "type": "TEXT"},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":            "pipeline",
+			"envProperties": properties,
+		})
+	}))
+	defer server.Close()
+
+	c, err := oc.NewOpenToolchainV1(&oc.OpenToolchainV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+
+	assert.NoError(t, err)
+
+	config := &ProviderConfig{
+		OTClient:      c,
+		PipelineLocks: newMutexKV(),
+	}
+
+	_, err = patchTektonPipeline(context.Background(), config, "pipeline", "us-south", func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		return &oc.PatchTektonPipelineOptions{
+			EnvProperties: []oc.EnvProperty{
+				{Name: getStringPtr("OURS"), Value: getStringPtr("new"), Type: getStringPtr("TEXT")},
+				{Name: getStringPtr("THEIRS"), Value: getStringPtr("value"), Type: getStringPtr("TEXT")},
+			},
+		}, nil
+	})
+
+	assert.EqualError(t, err, "tekton pipeline pipeline was modified concurrently, unexpected changes to property 'THEIRS', please re-apply")
+	assert.Equal(t, 1, gets)
+	assert.Equal(t, 1, patches)
+}
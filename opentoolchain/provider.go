@@ -8,7 +8,10 @@ import (
 
 	"github.com/IBM/go-sdk-core/core"
 	// v5core "github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/globalsearchv2"
 	"github.com/IBM/platform-services-go-sdk/globaltaggingv1"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,6 +21,15 @@ import (
 type ProviderConfig struct {
 	OTClient  *oc.OpenToolchainV1
 	TagClient *globaltaggingv1.GlobalTaggingV1
+	// used to read tags of many toolchains at once
+	SearchClient *globalsearchv2.GlobalSearchV2
+	// used to resolve and validate IBM Cloud service instances and resource groups referenced by integrations
+	ResourceControllerClient *resourcecontrollerv2.ResourceControllerV2
+	ResourceManagerClient    *resourcemanagerv2.ResourceManagerV2
+	// serializes read-modify-write cycles against the same pipeline GUID, only within this provider process
+	PipelineLocks *mutexKV
+	// serializes service instance creation within the same toolchain GUID
+	ToolchainLocks *mutexKV
 }
 
 func Provider() *schema.Provider {
@@ -37,12 +49,30 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_IAM_TOKEN", "IBMCLOUD_IAM_TOKEN", "IAM_ACCESS_TOKEN"}, nil),
 			},
+			"global_search_base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Global Search service base URL",
+				Default:     globalsearchv2.DefaultServiceURL,
+			},
 			"tags_base_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Global Tagging service base URL",
 				Default:     "https://tags.global-search-tagging.cloud.ibm.com",
 			},
+			"resource_controller_base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Resource Controller service base URL",
+				Default:     resourcecontrollerv2.DefaultServiceURL,
+			},
+			"resource_manager_base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Resource Manager service base URL",
+				Default:     resourcemanagerv2.DefaultServiceURL,
+			},
 			"iam_base_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -57,28 +87,65 @@ func Provider() *schema.Provider {
 			// },
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"opentoolchain_integration_github":        resourceOpenToolchainIntegrationGithub(),
-			"opentoolchain_integration_ibm_github":    resourceOpenToolchainIntegrationIBMGithub(),
-			"opentoolchain_integration_keyprotect":    resourceOpenToolchainIntegrationKeyProtect(),
-			"opentoolchain_integration_pagerduty":     resourceOpenToolchainIntegrationPagerDuty(),
-			"opentoolchain_integration_slack":         resourceOpenToolchainIntegrationSlack(),
-			"opentoolchain_toolchain":                 resourceOpenToolchainToolchain(),
-			"opentoolchain_pipeline_properties":       resourceOpenToolchainPipelineProperties(),
-			"opentoolchain_pipeline_triggers":         resourceOpenToolchainPipelineTriggers(),
-			"opentoolchain_tekton_pipeline":           resourceOpenToolchainTektonPipeline(),
-			"opentoolchain_tekton_pipeline_overrides": resourceOpenToolchainTektonPipelineOverrides(),
+			"opentoolchain_integration_app_configuration":   resourceOpenToolchainIntegrationAppConfiguration(),
+			"opentoolchain_integration_artifactory":         resourceOpenToolchainIntegrationArtifactory(),
+			"opentoolchain_integration_container_registry":  resourceOpenToolchainIntegrationContainerRegistry(),
+			"opentoolchain_integration_cos":                 resourceOpenToolchainIntegrationCOS(),
+			"opentoolchain_integration_custom_tool":         resourceOpenToolchainIntegrationCustomTool(),
+			"opentoolchain_integration_devops_insights":     resourceOpenToolchainIntegrationDevOpsInsights(),
+			"opentoolchain_integration_event_notifications": resourceOpenToolchainIntegrationEventNotifications(),
+			"opentoolchain_integration_github":              resourceOpenToolchainIntegrationGithub(),
+			"opentoolchain_integration_gitlab":              resourceOpenToolchainIntegrationGitlab(),
+			"opentoolchain_integration_hashicorp_vault":     resourceOpenToolchainIntegrationHashicorpVault(),
+			"opentoolchain_integration_hostedgit":           resourceOpenToolchainIntegrationHostedGit(),
+			"opentoolchain_integration_ibm_github":          resourceOpenToolchainIntegrationIBMGithub(),
+			"opentoolchain_integration_jira":                resourceOpenToolchainIntegrationJira(),
+			"opentoolchain_integration_keyprotect":          resourceOpenToolchainIntegrationKeyProtect(),
+			"opentoolchain_integration_msteams":             resourceOpenToolchainIntegrationMSTeams(),
+			"opentoolchain_integration_nexus":               resourceOpenToolchainIntegrationNexus(),
+			"opentoolchain_integration_pagerduty":           resourceOpenToolchainIntegrationPagerDuty(),
+			"opentoolchain_integration_private_worker":      resourceOpenToolchainIntegrationPrivateWorker(),
+			"opentoolchain_integration_secrets_manager":     resourceOpenToolchainIntegrationSecretsManager(),
+			"opentoolchain_integration_slack":               resourceOpenToolchainIntegrationSlack(),
+			"opentoolchain_integration_sonarqube":           resourceOpenToolchainIntegrationSonarQube(),
+			"opentoolchain_integration_webhook":             resourceOpenToolchainIntegrationWebhook(),
+			"opentoolchain_toolchain":                       resourceOpenToolchainToolchain(),
+			"opentoolchain_pipeline_properties":             resourceOpenToolchainPipelineProperties(),
+			"opentoolchain_pipeline_triggers":               resourceOpenToolchainPipelineTriggers(),
+			"opentoolchain_tekton_pipeline":                 resourceOpenToolchainTektonPipeline(),
+			"opentoolchain_tekton_pipeline_overrides":       resourceOpenToolchainTektonPipelineOverrides(),
+			"opentoolchain_tekton_pipeline_trigger":         resourceOpenToolchainTektonPipelineTrigger(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"opentoolchain_toolchain":              dataSourceOpenToolchainToolchain(),
-			"opentoolchain_integration_github":     dataSourceOpenToolchainIntegrationGithub(),
-			"opentoolchain_integration_ibm_github": dataSourceOpenToolchainIntegrationIBMGithub(),
-			"opentoolchain_integration_keyprotect": dataSourceOpenToolchainIntegrationKeyProtect(),
-			"opentoolchain_integration_pagerduty":  dataSourceOpenToolchainIntegrationPagerDuty(),
-			"opentoolchain_integration_slack":      dataSourceOpenToolchainIntegrationSlack(),
-			"opentoolchain_pipeline_properties":    dataSourceOpenToolchainPipelineProperties(),
-			"opentoolchain_pipeline_triggers":      dataSourceOpenToolchainPipelineTriggers(),
-			"opentoolchain_tekton_pipeline":        dataSourceOpenToolchainTektonPipeline(),
-			"opentoolchain_tekton_pipeline_config": dataSourceOpenToolchainTektonPipelineConfig(),
+			"opentoolchain_toolchain":                       dataSourceOpenToolchainToolchain(),
+			"opentoolchain_toolchains":                      dataSourceOpenToolchainToolchains(),
+			"opentoolchain_integration_app_configuration":   dataSourceOpenToolchainIntegrationAppConfiguration(),
+			"opentoolchain_integration_artifactory":         dataSourceOpenToolchainIntegrationArtifactory(),
+			"opentoolchain_integration_container_registry":  dataSourceOpenToolchainIntegrationContainerRegistry(),
+			"opentoolchain_integration_cos":                 dataSourceOpenToolchainIntegrationCOS(),
+			"opentoolchain_integration_custom_tool":         dataSourceOpenToolchainIntegrationCustomTool(),
+			"opentoolchain_integration_devops_insights":     dataSourceOpenToolchainIntegrationDevOpsInsights(),
+			"opentoolchain_integration_event_notifications": dataSourceOpenToolchainIntegrationEventNotifications(),
+			"opentoolchain_integration_github":              dataSourceOpenToolchainIntegrationGithub(),
+			"opentoolchain_integration_gitlab":              dataSourceOpenToolchainIntegrationGitlab(),
+			"opentoolchain_integration_hashicorp_vault":     dataSourceOpenToolchainIntegrationHashicorpVault(),
+			"opentoolchain_integration_hostedgit":           dataSourceOpenToolchainIntegrationHostedGit(),
+			"opentoolchain_integration_ibm_github":          dataSourceOpenToolchainIntegrationIBMGithub(),
+			"opentoolchain_integration_jira":                dataSourceOpenToolchainIntegrationJira(),
+			"opentoolchain_integration_keyprotect":          dataSourceOpenToolchainIntegrationKeyProtect(),
+			"opentoolchain_integration_msteams":             dataSourceOpenToolchainIntegrationMSTeams(),
+			"opentoolchain_integration_nexus":               dataSourceOpenToolchainIntegrationNexus(),
+			"opentoolchain_integration_pagerduty":           dataSourceOpenToolchainIntegrationPagerDuty(),
+			"opentoolchain_integration_private_worker":      dataSourceOpenToolchainIntegrationPrivateWorker(),
+			"opentoolchain_integration_slack":               dataSourceOpenToolchainIntegrationSlack(),
+			"opentoolchain_integration_sonarqube":           dataSourceOpenToolchainIntegrationSonarQube(),
+			"opentoolchain_integration_webhook":             dataSourceOpenToolchainIntegrationWebhook(),
+			"opentoolchain_integrations":                    dataSourceOpenToolchainIntegrations(),
+			"opentoolchain_pipeline_properties":             dataSourceOpenToolchainPipelineProperties(),
+			"opentoolchain_pipeline_triggers":               dataSourceOpenToolchainPipelineTriggers(),
+			"opentoolchain_tekton_pipeline":                 dataSourceOpenToolchainTektonPipeline(),
+			"opentoolchain_tekton_pipeline_config":          dataSourceOpenToolchainTektonPipelineConfig(),
+			"opentoolchain_tekton_pipelines":                dataSourceOpenToolchainTektonPipelines(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -104,6 +171,18 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		URL: d.Get("tags_base_url").(string),
 	}
 
+	searchClientOptions := &globalsearchv2.GlobalSearchV2Options{
+		URL: d.Get("global_search_base_url").(string),
+	}
+
+	rcClientOptions := &resourcecontrollerv2.ResourceControllerV2Options{
+		URL: d.Get("resource_controller_base_url").(string),
+	}
+
+	rmClientOptions := &resourcemanagerv2.ResourceManagerV2Options{
+		URL: d.Get("resource_manager_base_url").(string),
+	}
+
 	if iamAccessToken == "" {
 		if iamApiKey == "" {
 			return nil, diag.Errorf("iam_api_key or iam_access_token must be specified")
@@ -125,12 +204,18 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		}
 
 		tagClientOptions.Authenticator = otClientOptions.Authenticator
+		searchClientOptions.Authenticator = otClientOptions.Authenticator
+		rcClientOptions.Authenticator = otClientOptions.Authenticator
+		rmClientOptions.Authenticator = otClientOptions.Authenticator
 	} else {
 		otClientOptions.Authenticator = &core.BearerTokenAuthenticator{
 			BearerToken: iamAccessToken,
 		}
 
 		tagClientOptions.Authenticator = otClientOptions.Authenticator
+		searchClientOptions.Authenticator = otClientOptions.Authenticator
+		rcClientOptions.Authenticator = otClientOptions.Authenticator
+		rmClientOptions.Authenticator = otClientOptions.Authenticator
 	}
 
 	otClient, err := oc.NewOpenToolchainV1(otClientOptions)
@@ -158,8 +243,31 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.FromErr(err)
 	}
 
+	searchClient, err := globalsearchv2.NewGlobalSearchV2(searchClientOptions)
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	rcClient, err := resourcecontrollerv2.NewResourceControllerV2(rcClientOptions)
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	rmClient, err := resourcemanagerv2.NewResourceManagerV2(rmClientOptions)
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	return &ProviderConfig{
-		OTClient:  otClient,
-		TagClient: tagClient,
+		OTClient:                 otClient,
+		TagClient:                tagClient,
+		SearchClient:             searchClient,
+		ResourceControllerClient: rcClient,
+		ResourceManagerClient:    rmClient,
+		PipelineLocks:            newMutexKV(),
+		ToolchainLocks:           newMutexKV(),
 	}, diags
 }
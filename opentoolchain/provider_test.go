@@ -68,3 +68,9 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("RESOURCE_GROUP_NAME and RESOURCE_GROUP_ID must be set for acceptance tests")
 	}
 }
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
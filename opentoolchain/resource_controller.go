@@ -0,0 +1,126 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	rm "github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
+)
+
+// getCRNServiceName returns service name segment of CRN, example: `kms` for Key Protect
+func getCRNServiceName(crn string) string {
+	parts := strings.Split(crn, ":")
+
+	if len(parts) < 5 {
+		return ""
+	}
+
+	return parts[4]
+}
+
+// getCRNServiceInstance returns service instance segment of CRN, which is the resource instance GUID
+func getCRNServiceInstance(crn string) string {
+	parts := strings.Split(crn, ":")
+
+	if len(parts) < 8 {
+		return ""
+	}
+
+	return parts[7]
+}
+
+func getResourceGroupID(ctx context.Context, c *rm.ResourceManagerV2, name string) (string, error) {
+	groups, _, err := c.ListResourceGroupsWithContext(ctx, &rm.ListResourceGroupsOptions{
+		Name: &name,
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error reading resource groups: %s", err)
+	}
+
+	for _, g := range groups.Resources {
+		if g.Name != nil && *g.Name == name && g.ID != nil {
+			return *g.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("resource group %s not found", name)
+}
+
+// getResourceInstanceByCRN returns resource instance, verifying that it belongs to expected service
+func getResourceInstanceByCRN(ctx context.Context, c *rc.ResourceControllerV2, crn string, serviceName string) (*rc.ResourceInstance, error) {
+	if getCRNServiceName(crn) != serviceName {
+		return nil, fmt.Errorf("%s is not a %s instance CRN", crn, serviceName)
+	}
+
+	instance, resp, err := c.GetResourceInstanceWithContext(ctx, &rc.GetResourceInstanceOptions{
+		ID: &crn,
+	})
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, fmt.Errorf("resource instance %s not found", crn)
+		}
+
+		return nil, fmt.Errorf("error reading resource instance %s: %s", crn, err)
+	}
+
+	return instance, nil
+}
+
+// findResourceInstance looks up service instance by name, region (`ibm:yp:us-east` or `us-east`) and resource group name
+func findResourceInstance(ctx context.Context, config *ProviderConfig, name string, region string, resourceGroup string, serviceName string) (*rc.ResourceInstance, error) {
+	groupID, err := getResourceGroupID(ctx, config.ResourceManagerClient, resourceGroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	regionParts := strings.Split(region, ":")
+	regionID := regionParts[len(regionParts)-1]
+
+	options := &rc.ListResourceInstancesOptions{
+		Name:            &name,
+		ResourceGroupID: &groupID,
+	}
+
+	// instances are paged, keep reading until there is no next page
+	for {
+		instances, _, err := config.ResourceControllerClient.ListResourceInstancesWithContext(ctx, options)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading resource instances: %s", err)
+		}
+
+		for _, i := range instances.Resources {
+			if i.CRN == nil || getCRNServiceName(*i.CRN) != serviceName {
+				continue
+			}
+
+			if i.RegionID != nil && *i.RegionID == regionID {
+				return &i, nil
+			}
+		}
+
+		if instances.NextURL == nil || *instances.NextURL == "" {
+			break
+		}
+
+		start, err := core.GetQueryParam(instances.NextURL, "start")
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading resource instances next page: %s", err)
+		}
+
+		if start == nil {
+			break
+		}
+
+		options.Start = start
+	}
+
+	return nil, fmt.Errorf("%s instance %s not found in region %s, resource group %s", serviceName, name, regionID, resourceGroup)
+}
@@ -0,0 +1,95 @@
+package opentoolchain
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/go-sdk-core/v5/core"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	rm "github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetCRNSegments(t *testing.T) {
+	crn := "crn:v1:bluemix:public:kms:us-south:a/0123456789:1f2d3c4b-5a69-4786-9504-d3c2b1a09f8e::"
+
+	assert.Equal(t, "kms", getCRNServiceName(crn))
+	assert.Equal(t, "1f2d3c4b-5a69-4786-9504-d3c2b1a09f8e", getCRNServiceInstance(crn))
+	assert.Equal(t, "", getCRNServiceName("invalid"))
+	assert.Equal(t, "", getCRNServiceInstance("crn:v1:bluemix:public:kms"))
+}
+
+func TestFindResourceInstancePaging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var result map[string]interface{}
+
+		switch r.URL.Path {
+		case "/v2/resource_groups":
+			result = map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{"id": "rg-1", "name": "default"},
+				},
+			}
+		case "/v2/resource_instances":
+			assert.Equal(t, "kp", r.URL.Query().Get("name"))
+			assert.Equal(t, "rg-1", r.URL.Query().Get("resource_group_id"))
+
+			if r.URL.Query().Get("start") == "" {
+				// first page only has instance in another region
+				result = map[string]interface{}{
+					"rows_count": 1,
+					"next_url":   "/v2/resource_instances?name=kp&resource_group_id=rg-1&start=page-2",
+					"resources": []interface{}{
+						map[string]interface{}{"guid": "other", "crn": "crn:v1:bluemix:public:kms:eu-de:a/123:other::", "region_id": "eu-de"},
+					},
+				}
+			} else {
+				assert.Equal(t, "page-2", r.URL.Query().Get("start"))
+
+				result = map[string]interface{}{
+					"rows_count": 1,
+					"next_url":   nil,
+					"resources": []interface{}{
+						map[string]interface{}{"guid": "match", "crn": "crn:v1:bluemix:public:kms:us-south:a/123:match::", "region_id": "us-south"},
+					},
+				}
+			}
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	rcClient, err := rc.NewResourceControllerV2(&rc.ResourceControllerV2Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+
+	assert.NoError(t, err)
+
+	rmClient, err := rm.NewResourceManagerV2(&rm.ResourceManagerV2Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+
+	assert.NoError(t, err)
+
+	config := &ProviderConfig{
+		ResourceControllerClient: rcClient,
+		ResourceManagerClient:    rmClient,
+	}
+
+	instance, err := findResourceInstance(context.Background(), config, "kp", "ibm:yp:us-south", "default", "kms")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "match", *instance.GUID)
+	}
+
+	_, err = findResourceInstance(context.Background(), config, "kp", "ibm:yp:us-east", "default", "kms")
+	assert.EqualError(t, err, "kms instance kp not found in region us-east, resource group default")
+}
@@ -0,0 +1,15 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	appConfigurationIntegrationServiceType = "appconfig"
+)
+
+var appConfigurationIntegration = newCloudInstanceIntegration(appConfigurationIntegrationServiceType, "App Configuration")
+
+func resourceOpenToolchainIntegrationAppConfiguration() *schema.Resource {
+	return appConfigurationIntegration.resource("Manage IBM Cloud App Configuration integration (WARN: using undocumented APIs)")
+}
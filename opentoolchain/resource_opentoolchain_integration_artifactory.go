@@ -0,0 +1,15 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	artifactoryIntegrationServiceType = "artifactory"
+)
+
+var artifactoryIntegration = newArtifactRepositoryIntegration(artifactoryIntegrationServiceType, "Artifactory", "dashboard_url", []string{"docker", "maven", "npm"})
+
+func resourceOpenToolchainIntegrationArtifactory() *schema.Resource {
+	return artifactoryIntegration.resource("Manage Artifactory integration (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,15 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	containerRegistryIntegrationServiceType = "containerregistry"
+)
+
+var containerRegistryIntegration = newCloudInstanceIntegration(containerRegistryIntegrationServiceType, "Container Registry")
+
+func resourceOpenToolchainIntegrationContainerRegistry() *schema.Resource {
+	return containerRegistryIntegration.resource("Manage IBM Cloud Container Registry integration (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,15 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	cosIntegrationServiceType = "cloudobjectstorage"
+)
+
+var cosIntegration = newCloudInstanceIntegration(cosIntegrationServiceType, "Cloud Object Storage")
+
+func resourceOpenToolchainIntegrationCOS() *schema.Resource {
+	return cosIntegration.resource("Manage IBM Cloud Object Storage integration (WARN: using undocumented APIs)")
+}
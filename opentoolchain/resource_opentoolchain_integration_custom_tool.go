@@ -0,0 +1,90 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	customToolIntegrationServiceType = "customtool"
+)
+
+var customToolLifecyclePhases = []string{"THINK", "CODE", "DELIVER", "RUN", "MANAGE", "LEARN", "CULTURE"}
+
+var customToolIntegration = &serviceInstanceIntegration{
+	serviceType: customToolIntegrationServiceType,
+	title:       "Custom Tool",
+	schema: map[string]*schema.Schema{
+		"name": {
+			Description: "Tool instance name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"type": {
+			Description: "Tool type shown on toolchain card, for example: `Grafana`",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"description": {
+			Description: "Tool description",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"url": {
+			Description:  "Tool URL",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+		},
+		"image_url": {
+			Description:  "Tool icon URL",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+		},
+		"lifecycle_phase": {
+			Description:  "Toolchain lifecycle phase the tool belongs to, one of: `THINK`, `CODE`, `DELIVER`, `RUN`, `MANAGE`, `LEARN`, `CULTURE`",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "MANAGE",
+			ValidateFunc: validation.StringInSlice(customToolLifecyclePhases, false),
+		},
+		"additional_properties": {
+			Description: "Additional tool properties",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	},
+	params: map[string]string{
+		"name":            "instance_name",
+		"type":            "type",
+		"description":     "description",
+		"url":             "dashboard_url",
+		"image_url":       "image_url",
+		"lifecycle_phase": "lifecycle_phase",
+	},
+	nameParam: "instance_name",
+	expand: func(d *schema.ResourceData, params map[string]interface{}) {
+		params["additional_properties"] = d.Get("additional_properties").(map[string]interface{})
+	},
+	flatten: func(params map[string]interface{}, result map[string]interface{}) {
+		props := make(map[string]interface{})
+
+		if p, ok := params["additional_properties"].(map[string]interface{}); ok {
+			for k, v := range p {
+				if s, ok := v.(string); ok {
+					props[k] = s
+				}
+			}
+		}
+
+		result["additional_properties"] = props
+	},
+}
+
+func resourceOpenToolchainIntegrationCustomTool() *schema.Resource {
+	return customToolIntegration.resource("Manage custom tool (\"Other Tool\") integration (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,21 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	devopsInsightsIntegrationServiceType = "draservicebroker"
+)
+
+// DevOps Insights integration has no configurable parameters, new instance is located by comparing
+// toolchain service instances before and after creation
+var devopsInsightsIntegration = &serviceInstanceIntegration{
+	serviceType: devopsInsightsIntegrationServiceType,
+	title:       "DevOps Insights",
+	schema:      map[string]*schema.Schema{},
+}
+
+func resourceOpenToolchainIntegrationDevOpsInsights() *schema.Resource {
+	return devopsInsightsIntegration.resource("Manage DevOps Insights integration (WARN: using undocumented APIs)")
+}
@@ -0,0 +1,64 @@
+package opentoolchain
+
+import (
+	"context"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+const (
+	eventNotificationsIntegrationServiceType = "event_notifications"
+)
+
+var eventNotificationsIntegration = &serviceInstanceIntegration{
+	serviceType: eventNotificationsIntegrationServiceType,
+	title:       "Event Notifications",
+	schema: map[string]*schema.Schema{
+		"name": {
+			Description: "Integration name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"instance_crn": {
+			Description: "Event Notifications instance CRN",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"description": {
+			Description: "Integration description",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"webhook_id": {
+			Description: "Toolchain binding webhook ID, can be used as toolchain `lifecycle_messaging_webhook_id`",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	},
+	params: map[string]string{
+		"name":         "name",
+		"instance_crn": "instance_crn",
+		"description":  "description",
+	},
+	nameParam: "name",
+	readExtra: readEventNotificationsWebhookID,
+}
+
+func resourceOpenToolchainIntegrationEventNotifications() *schema.Resource {
+	return eventNotificationsIntegration.resource("Manage IBM Cloud Event Notifications integration (WARN: using undocumented APIs)")
+}
+
+func readEventNotificationsWebhookID(ctx context.Context, config *ProviderConfig, d *schema.ResourceData, svc *oc.GetServiceInstanceResponseServiceInstance, integrationID string, toolchainID string, envID string) error {
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	webhookID, err := getServiceInstanceWebhookID(ctx, config.OTClient, toolchainID, region, integrationID)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("webhook_id", webhookID)
+	return nil
+}
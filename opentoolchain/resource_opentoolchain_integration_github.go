@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
-	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strings"
 )
@@ -14,6 +15,8 @@ import (
 //
 const (
 	githubIntegrationServiceType = "githubconsolidated"
+	githubGitID                  = "github"
+	githubDefaultServerURL       = "https://github.com"
 )
 
 func resourceOpenToolchainIntegrationGithub() *schema.Resource {
@@ -26,6 +29,11 @@ func resourceOpenToolchainIntegrationGithub() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeGitRepoTypeDiff,
+			customizeGitAuthDiff,
+			customizeSecretHashDiff("api_token", "api_token_hash"),
+		),
 		Schema: map[string]*schema.Schema{
 			"toolchain_id": {
 				Description: "The toolchain `guid`",
@@ -44,20 +52,50 @@ func resourceOpenToolchainIntegrationGithub() *schema.Resource {
 				ForceNew:    true,
 				Required:    true,
 			},
+			"server_url": {
+				Description:      "GitHub server URL, set it for GitHub Enterprise servers",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          githubDefaultServerURL,
+				ValidateFunc:     validation.IsURLWithHTTPS,
+				DiffSuppressFunc: suppressGitServerURLDiff,
+			},
+			"repo_type": {
+				Description:  "Repository type: `link` to existing repository, `clone`, `fork` or create `new` repository",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "link",
+				ValidateFunc: validation.StringInSlice([]string{"link", "clone", "fork", "new"}, false),
+			},
 			"repo_url": {
-				Description: "Github repository url",
+				Description:      "Github repository url, required if `repo_type` is `link`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressGitSuffixDiff,
+			},
+			"source_repo_url": {
+				Description: "Source repository url, required if `repo_type` is `clone` or `fork`",
 				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"repo_name": {
+				Description: "New repository name, required if `repo_type` is `clone`, `fork` or `new`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"owner_id": {
+				Description: "GitHub user or organization that owns new repository, used if `repo_type` is `clone`, `fork` or `new`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Required:    true,
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					if old == new {
-						return true
-					}
-					if strings.TrimSuffix(old, ".git") == strings.TrimSuffix(new, ".git") {
-						return true
-					}
-					return false
-				},
 			},
 			"private": {
 				Description: "Set `true` if repository is private",
@@ -77,6 +115,31 @@ func resourceOpenToolchainIntegrationGithub() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"auth_type": {
+				Description:  "Authentication type: `oauth` or personal access token (`pat`)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "oauth",
+				ValidateFunc: validation.StringInSlice([]string{"oauth", "pat"}, false),
+			},
+			"api_token": {
+				Description: "GitHub personal access token, required if `auth_type` is `pat`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"encrypted_api_token": {
+				Description: "Since API only provides encrypted token value, we can use that internally to detect changes made outside of Terraform",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"api_token_hash": {
+				Description: "Salted hash of `api_token`, used internally to detect changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
 		},
 	}
 }
@@ -84,90 +147,47 @@ func resourceOpenToolchainIntegrationGithub() *schema.Resource {
 func resourceOpenToolchainIntegrationGithubCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
-	repoURL := d.Get("repo_url").(string)
-	private := d.Get("private").(bool)
-	enableIssues := d.Get("enable_issues").(bool)
-	enableTraceability := d.Get("enable_traceability").(bool)
-
-	envIDParts := strings.Split(envID, ":")
-	region := envIDParts[len(envIDParts)-1]
+	serverURL := d.Get("server_url").(string)
+	repoType := d.Get("repo_type").(string)
+	apiToken := d.Get("api_token").(string)
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
-	integrationUUID := uuid.NewString()
-	uuidURL := fmt.Sprintf("%s/%s", repoURL, integrationUUID)
-
-	options := &oc.CreateServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		EnvID:       &envID,
-		ServiceID:   getStringPtr(githubIntegrationServiceType),
-		Parameters: &oc.CreateServiceInstanceParamsParameters{
-			Authorized:         getStringPtr("github"),
-			GitID:              getStringPtr("github"),
-			Legal:              getBoolPtr(false),
-			RepoURL:            &uuidURL,
-			Type:               getStringPtr("link"),
-			PrivateRepo:        &private,
-			HasIssues:          &enableIssues,
-			EnableTraceability: &enableTraceability,
-		},
-	}
-
-	_, _, err := c.CreateServiceInstanceWithContext(ctx, options)
-
-	if err != nil {
-		return diag.Errorf("Error creating Github integration: %s", err)
-	}
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
 
-	response, _, err := c.GetToolchainWithContext(ctx, &oc.GetToolchainOptions{
-		GUID:    &toolchainID,
-		Region:  &region,
-		Include: getStringPtr("fields,services"),
-	})
+	params, err := getGitServerParams(githubGitID, githubDefaultServerURL, "/api/v3", serverURL)
 
 	if err != nil {
-		return diag.Errorf("Error reading toolchain: %s", err)
+		return diag.Errorf("Invalid GitHub server URL: %s", err)
 	}
 
-	if len(response.Items) == 0 {
-		return diag.Errorf("No toolchain found with GUID: %s", toolchainID)
-	}
+	params["type"] = repoType
+	params["legal"] = false
+	params["private_repo"] = d.Get("private").(bool)
+	params["has_issues"] = d.Get("enable_issues").(bool)
+	params["enable_traceability"] = d.Get("enable_traceability").(bool)
+	params["auth_type"] = d.Get("auth_type").(string)
 
-	var instanceID string
-
-	toolchain := response.Items[0]
-
-	// find new service instance
-	if toolchain.Services != nil {
-		for _, v := range toolchain.Services {
-			if v.ServiceID != nil && *v.ServiceID == githubIntegrationServiceType && v.Parameters != nil && v.Parameters["repo_url"] == uuidURL && v.InstanceID != nil {
-				instanceID = *v.InstanceID
-				break
-			}
+	for _, k := range []string{"repo_url", "source_repo_url", "repo_name", "owner_id"} {
+		if v, ok := d.GetOk(k); ok {
+			params[k] = v.(string)
 		}
 	}
 
-	if instanceID == "" {
-		// no way to cleanup since we don't know github integration GUID
-		return diag.Errorf("Unable to determine Github integration GUID")
+	if apiToken != "" {
+		params["api_token"] = apiToken
 	}
 
-	_, err = c.PatchServiceInstanceWithContext(ctx, &oc.PatchServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		GUID:        &instanceID,
-		EnvID:       &envID,
-		ServiceID:   getStringPtr(githubIntegrationServiceType),
-		Parameters: &oc.PatchServiceInstanceParamsParameters{
-			RepoURL: &repoURL,
-		},
-	})
+	instanceID, err := createServiceInstanceWithID(ctx, c, toolchainID, envID, githubIntegrationServiceType, params)
 
 	if err != nil {
-		return diag.Errorf("Unable to update Github URL: %s", err)
+		return diag.Errorf("Error creating Github integration: %s", err)
 	}
 
 	d.Set("integration_id", instanceID)
+	d.Set("api_token_hash", hashSecret(apiToken))
 	d.SetId(fmt.Sprintf("%s/%s/%s", instanceID, toolchainID, envID))
 
 	return resourceOpenToolchainIntegrationGithubRead(ctx, d, m)
@@ -211,24 +231,14 @@ func resourceOpenToolchainIntegrationGithubRead(ctx context.Context, d *schema.R
 	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
 		params := svc.ServiceInstance.Parameters
 
-		if u, ok := params["repo_url"]; ok {
-			url := u.(string)
-			d.Set("repo_url", url)
-		}
-
-		if p, ok := params["private_repo"]; ok {
-			private := p.(bool)
-			d.Set("private", private)
-		}
-
-		if h, ok := params["has_issues"]; ok {
-			hasIssues := h.(bool)
-			d.Set("enable_issues", hasIssues)
+		for k, v := range flattenGitIntegrationParams(githubGitID, githubDefaultServerURL, params) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting github integration %s: %s", k, err)
+			}
 		}
 
-		if e, ok := params["enable_traceability"]; ok {
-			enableTraceability := e.(bool)
-			d.Set("enable_traceability", enableTraceability)
+		if t, ok := params["api_token"].(string); ok {
+			readSecretDrift(d, "encrypted_api_token", "api_token_hash", t)
 		}
 	}
 
@@ -261,30 +271,40 @@ func resourceOpenToolchainIntegrationGithubUpdate(ctx context.Context, d *schema
 	instanceID := d.Get("integration_id").(string)
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
+	apiToken := d.Get("api_token").(string)
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
-	if d.HasChange("private") || d.HasChange("enable_issues") || d.HasChange("enable_traceability") {
-		private := d.Get("private").(bool)
-		enableIssues := d.Get("enable_issues").(bool)
-		enableTraceability := d.Get("enable_traceability").(bool)
-
-		_, err := c.PatchServiceInstanceWithContext(ctx, &oc.PatchServiceInstanceOptions{
-			ToolchainID: &toolchainID,
-			GUID:        &instanceID,
-			EnvID:       &envID,
-			ServiceID:   getStringPtr(githubIntegrationServiceType),
-			Parameters: &oc.PatchServiceInstanceParamsParameters{
-				PrivateRepo:        &private,
-				HasIssues:          &enableIssues,
-				EnableTraceability: &enableTraceability,
-			},
-		})
+	tokenChanged := secretChanged(d, "api_token", "api_token_hash")
+
+	if d.HasChange("private") || d.HasChange("enable_issues") || d.HasChange("enable_traceability") || d.HasChange("auth_type") || tokenChanged {
+		params := map[string]interface{}{
+			"private_repo":        d.Get("private").(bool),
+			"has_issues":          d.Get("enable_issues").(bool),
+			"enable_traceability": d.Get("enable_traceability").(bool),
+			"auth_type":           d.Get("auth_type").(string),
+		}
+
+		// token is only used for personal access token authentication, clear stale token otherwise
+		if d.Get("auth_type").(string) != "pat" {
+			params["api_token"] = ""
+		} else if apiToken != "" {
+			params["api_token"] = apiToken
+		}
+
+		err := patchServiceInstance(ctx, c, toolchainID, envID, githubIntegrationServiceType, instanceID, params)
 
 		if err != nil {
 			return diag.Errorf("Unable to update Github integration: %s", err)
 		}
+
+		if apiToken != "" {
+			// token is sent with every update, API re-encrypts it
+			d.Set("encrypted_api_token", "")
+		}
+
+		d.Set("api_token_hash", hashSecret(apiToken))
 	}
 
 	return resourceOpenToolchainIntegrationGithubRead(ctx, d, m)
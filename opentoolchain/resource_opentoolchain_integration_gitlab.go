@@ -0,0 +1,310 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+)
+
+const (
+	gitlabIntegrationServiceType = "gitlab"
+	gitlabGitID                  = "gitlab"
+	gitlabDefaultServerURL       = "https://gitlab.com"
+)
+
+func resourceOpenToolchainIntegrationGitlab() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage GitLab integration (WARN: using undocumented APIs)",
+		CreateContext: resourceOpenToolchainIntegrationGitlabCreate,
+		ReadContext:   resourceOpenToolchainIntegrationGitlabRead,
+		DeleteContext: resourceOpenToolchainIntegrationGitlabDelete,
+		UpdateContext: resourceOpenToolchainIntegrationGitlabUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeGitRepoTypeDiff,
+			customizeGitAuthDiff,
+			customizeSecretHashDiff("api_token", "api_token_hash"),
+		),
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"integration_id": {
+				Description: "The integration `guid`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"server_url": {
+				Description:      "GitLab server URL, set it for self-managed GitLab servers",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          gitlabDefaultServerURL,
+				ValidateFunc:     validation.IsURLWithHTTPS,
+				DiffSuppressFunc: suppressGitServerURLDiff,
+			},
+			"repo_type": {
+				Description:  "Repository type: `link` to existing repository, `clone`, `fork` or create `new` repository",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "link",
+				ValidateFunc: validation.StringInSlice([]string{"link", "clone", "fork", "new"}, false),
+			},
+			"repo_url": {
+				Description:      "GitLab repository url, required if `repo_type` is `link`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressGitSuffixDiff,
+			},
+			"source_repo_url": {
+				Description: "Source repository url, required if `repo_type` is `clone` or `fork`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"repo_name": {
+				Description: "New repository name, required if `repo_type` is `clone`, `fork` or `new`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"owner_id": {
+				Description: "GitLab user or group that owns new repository, used if `repo_type` is `clone`, `fork` or `new`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"private": {
+				Description: "Set `true` if repository is private",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"enable_issues": {
+				Description: "Enable GitLab issues",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"enable_traceability": {
+				Description: "Track deployment of code changes",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"auth_type": {
+				Description:  "Authentication type: `oauth` or personal access token (`pat`)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "oauth",
+				ValidateFunc: validation.StringInSlice([]string{"oauth", "pat"}, false),
+			},
+			"api_token": {
+				Description: "GitLab personal access token, required if `auth_type` is `pat`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"encrypted_api_token": {
+				Description: "Since API only provides encrypted token value, we can use that internally to detect changes made outside of Terraform",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"api_token_hash": {
+				Description: "Salted hash of `api_token`, used internally to detect changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func resourceOpenToolchainIntegrationGitlabCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+	serverURL := d.Get("server_url").(string)
+	repoType := d.Get("repo_type").(string)
+	apiToken := d.Get("api_token").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
+
+	params, err := getGitServerParams(gitlabGitID, gitlabDefaultServerURL, "/api/v4", serverURL)
+
+	if err != nil {
+		return diag.Errorf("Invalid GitLab server URL: %s", err)
+	}
+
+	params["type"] = repoType
+	params["legal"] = false
+	params["private_repo"] = d.Get("private").(bool)
+	params["has_issues"] = d.Get("enable_issues").(bool)
+	params["enable_traceability"] = d.Get("enable_traceability").(bool)
+	params["auth_type"] = d.Get("auth_type").(string)
+
+	for _, k := range []string{"repo_url", "source_repo_url", "repo_name", "owner_id"} {
+		if v, ok := d.GetOk(k); ok {
+			params[k] = v.(string)
+		}
+	}
+
+	if apiToken != "" {
+		params["api_token"] = apiToken
+	}
+
+	instanceID, err := createServiceInstanceWithID(ctx, c, toolchainID, envID, gitlabIntegrationServiceType, params)
+
+	if err != nil {
+		return diag.Errorf("Error creating GitLab integration: %s", err)
+	}
+
+	d.Set("integration_id", instanceID)
+	d.Set("api_token_hash", hashSecret(apiToken))
+	d.SetId(fmt.Sprintf("%s/%s/%s", instanceID, toolchainID, envID))
+
+	return resourceOpenToolchainIntegrationGitlabRead(ctx, d, m)
+}
+
+func resourceOpenToolchainIntegrationGitlabRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Id()
+	idParts := strings.Split(id, "/")
+
+	if len(idParts) < 3 {
+		return diag.Errorf("Incorrect ID %s: ID should be a combination of integrationID/toolchainID/envID", d.Id())
+	}
+
+	integrationID := idParts[0]
+	toolchainID := idParts[1]
+	envID := idParts[2]
+
+	d.Set("integration_id", integrationID)
+	d.Set("toolchain_id", toolchainID)
+	d.Set("env_id", envID)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	svc, resp, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("[WARN] GitLab service instance '%s' is not found, removing it from state", integrationID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("Error reading gitlab service instance: %s", err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		params := svc.ServiceInstance.Parameters
+
+		for k, v := range flattenGitIntegrationParams(gitlabGitID, gitlabDefaultServerURL, params) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting gitlab integration %s: %s", k, err)
+			}
+		}
+
+		if t, ok := params["api_token"].(string); ok {
+			readSecretDrift(d, "encrypted_api_token", "api_token_hash", t)
+		}
+	}
+
+	return nil
+}
+
+func resourceOpenToolchainIntegrationGitlabDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	integrationID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	_, err := c.DeleteServiceInstanceWithContext(ctx, &oc.DeleteServiceInstanceOptions{
+		GUID:        &integrationID,
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error deleting GitLab integration: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceOpenToolchainIntegrationGitlabUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+	apiToken := d.Get("api_token").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	tokenChanged := secretChanged(d, "api_token", "api_token_hash")
+
+	if d.HasChange("private") || d.HasChange("enable_issues") || d.HasChange("enable_traceability") || d.HasChange("auth_type") || tokenChanged {
+		params := map[string]interface{}{
+			"private_repo":        d.Get("private").(bool),
+			"has_issues":          d.Get("enable_issues").(bool),
+			"enable_traceability": d.Get("enable_traceability").(bool),
+			"auth_type":           d.Get("auth_type").(string),
+		}
+
+		// token is only used for personal access token authentication, clear stale token otherwise
+		if d.Get("auth_type").(string) != "pat" {
+			params["api_token"] = ""
+		} else if apiToken != "" {
+			params["api_token"] = apiToken
+		}
+
+		err := patchServiceInstance(ctx, c, toolchainID, envID, gitlabIntegrationServiceType, instanceID, params)
+
+		if err != nil {
+			return diag.Errorf("Unable to update GitLab integration: %s", err)
+		}
+
+		if apiToken != "" {
+			// token is sent with every update, API re-encrypts it
+			d.Set("encrypted_api_token", "")
+		}
+
+		d.Set("api_token_hash", hashSecret(apiToken))
+	}
+
+	return resourceOpenToolchainIntegrationGitlabRead(ctx, d, m)
+}
@@ -0,0 +1,369 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+)
+
+const (
+	hashicorpVaultIntegrationServiceType = "hashicorpvault"
+)
+
+// sensitive vault integration attributes, each one has `encrypted_<attr>` and `<attr>_hash` companions
+var hashicorpVaultSecretAttrs = []string{"token", "role_id", "secret_id", "password"}
+
+func resourceOpenToolchainIntegrationHashicorpVault() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage HashiCorp Vault integration (WARN: using undocumented APIs)",
+		CreateContext: resourceOpenToolchainIntegrationHashicorpVaultCreate,
+		ReadContext:   resourceOpenToolchainIntegrationHashicorpVaultRead,
+		DeleteContext: resourceOpenToolchainIntegrationHashicorpVaultDelete,
+		UpdateContext: resourceOpenToolchainIntegrationHashicorpVaultUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.All(
+			customizeHashicorpVaultAuthDiff,
+			customizeSecretHashDiff("token", "token_hash"),
+			customizeSecretHashDiff("role_id", "role_id_hash"),
+			customizeSecretHashDiff("secret_id", "secret_id_hash"),
+			customizeSecretHashDiff("password", "password_hash"),
+		),
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"integration_id": {
+				Description: "The integration `guid`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"name": {
+				Description: "Integration name, used in secret references: `{vault::integration_name.SECRET_NAME}`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"server_url": {
+				Description:  "HashiCorp Vault server URL, example: `https://vault.example.com:8200`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+			"dashboard_url": {
+				Description:  "HashiCorp Vault dashboard URL",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+			"path": {
+				Description: "Path to the secrets engine, example: `secret/data/toolchain`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"auth_method": {
+				Description:  "Authentication method: `token`, `approle` or `userpass`",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"token", "approle", "userpass"}, false),
+			},
+			"token": {
+				Description: "Vault token, required if `auth_method` is `token`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"role_id": {
+				Description: "AppRole role ID, required if `auth_method` is `approle`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"secret_id": {
+				Description: "AppRole secret ID, required if `auth_method` is `approle`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"username": {
+				Description: "Vault username, required if `auth_method` is `userpass`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"password": {
+				Description: "Vault password, required if `auth_method` is `userpass`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"encrypted_token": {
+				Description: "Since API only provides encrypted token value, we can use that internally to detect changes made outside of Terraform",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token_hash": {
+				Description: "Salted hash of `token`, used internally to detect changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"encrypted_role_id": {
+				Description: "Since API only provides encrypted role ID value, we can use that internally to detect changes made outside of Terraform",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"role_id_hash": {
+				Description: "Salted hash of `role_id`, used internally to detect changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"encrypted_secret_id": {
+				Description: "Since API only provides encrypted secret ID value, we can use that internally to detect changes made outside of Terraform",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"secret_id_hash": {
+				Description: "Salted hash of `secret_id`, used internally to detect changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"encrypted_password": {
+				Description: "Since API only provides encrypted password value, we can use that internally to detect changes made outside of Terraform",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"password_hash": {
+				Description: "Salted hash of `password`, used internally to detect changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"secret_reference_prefix": {
+				Description: "Prefix for secret references, for example: `\"${opentoolchain_integration_hashicorp_vault.vault.secret_reference_prefix}SECRET_NAME}\"`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceOpenToolchainIntegrationHashicorpVaultCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
+
+	// vault integration name is referenced by other integrations as {vault::name.KEY}, so it is never changed, not even temporarily
+	integrationID, err := createServiceInstanceWithID(ctx, c, toolchainID, envID, hashicorpVaultIntegrationServiceType, expandHashicorpVaultIntegrationParams(d))
+
+	if err != nil {
+		return diag.Errorf("Error creating HashiCorp Vault integration: %s", err)
+	}
+
+	for _, k := range hashicorpVaultSecretAttrs {
+		d.Set(k+"_hash", hashSecret(d.Get(k).(string)))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
+
+	return resourceOpenToolchainIntegrationHashicorpVaultRead(ctx, d, m)
+}
+
+func resourceOpenToolchainIntegrationHashicorpVaultRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Id()
+	idParts := strings.Split(id, "/")
+
+	if len(idParts) < 3 {
+		return diag.Errorf("Incorrect ID %s: ID should be a combination of integrationID/toolchainID/envID", d.Id())
+	}
+
+	integrationID := idParts[0]
+	toolchainID := idParts[1]
+	envID := idParts[2]
+
+	d.Set("integration_id", integrationID)
+	d.Set("toolchain_id", toolchainID)
+	d.Set("env_id", envID)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	svc, resp, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("[WARN] HashiCorp Vault service instance '%s' is not found, removing it from state", integrationID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("Error reading hashicorp vault service instance: %s", err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		params := svc.ServiceInstance.Parameters
+
+		for k, v := range flattenHashicorpVaultIntegrationParams(params) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting hashicorp vault integration %s: %s", k, err)
+			}
+		}
+
+		for _, k := range hashicorpVaultSecretAttrs {
+			if v, ok := params[k].(string); ok {
+				readSecretDrift(d, "encrypted_"+k, k+"_hash", v)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceOpenToolchainIntegrationHashicorpVaultDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	integrationID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	_, err := c.DeleteServiceInstanceWithContext(ctx, &oc.DeleteServiceInstanceOptions{
+		GUID:        &integrationID,
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error deleting HashiCorp Vault integration: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceOpenToolchainIntegrationHashicorpVaultUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	changed := d.HasChanges("name", "server_url", "dashboard_url", "path", "auth_method", "username")
+
+	for _, k := range hashicorpVaultSecretAttrs {
+		changed = changed || secretChanged(d, k, k+"_hash")
+	}
+
+	if changed {
+		err := patchServiceInstance(ctx, c, toolchainID, envID, hashicorpVaultIntegrationServiceType, instanceID, expandHashicorpVaultIntegrationParams(d))
+
+		if err != nil {
+			return diag.Errorf("Unable to update HashiCorp Vault integration: %s", err)
+		}
+
+		// secrets are always sent, API re-encrypts them
+		for _, k := range hashicorpVaultSecretAttrs {
+			d.Set("encrypted_"+k, "")
+			d.Set(k+"_hash", hashSecret(d.Get(k).(string)))
+		}
+	}
+
+	return resourceOpenToolchainIntegrationHashicorpVaultRead(ctx, d, m)
+}
+
+func expandHashicorpVaultIntegrationParams(d *schema.ResourceData) map[string]interface{} {
+	params := map[string]interface{}{
+		"name":                  d.Get("name").(string),
+		"server_url":            d.Get("server_url").(string),
+		"dashboard_url":         d.Get("dashboard_url").(string),
+		"path":                  d.Get("path").(string),
+		"authentication_method": d.Get("auth_method").(string),
+		"username":              d.Get("username").(string),
+	}
+
+	for _, k := range hashicorpVaultSecretAttrs {
+		if v, ok := d.GetOk(k); ok {
+			params[k] = v.(string)
+		}
+	}
+
+	return params
+}
+
+// flattenHashicorpVaultIntegrationParams maps service instance parameters to vault integration attributes,
+// secrets are handled separately
+func flattenHashicorpVaultIntegrationParams(params map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	for param, attr := range map[string]string{
+		"name":                  "name",
+		"server_url":            "server_url",
+		"dashboard_url":         "dashboard_url",
+		"path":                  "path",
+		"authentication_method": "auth_method",
+		"username":              "username",
+	} {
+		if v, ok := params[param].(string); ok && v != "" {
+			result[attr] = v
+		}
+	}
+
+	if name, ok := result["name"].(string); ok {
+		result["secret_reference_prefix"] = getSecretReferencePrefix(name)
+	}
+
+	return result
+}
+
+// customizeHashicorpVaultAuthDiff checks that credentials required by selected `auth_method` are set
+func customizeHashicorpVaultAuthDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("auth_method") {
+		return nil
+	}
+
+	authMethod := d.Get("auth_method").(string)
+
+	required := map[string][]string{
+		"token":    {"token"},
+		"approle":  {"role_id", "secret_id"},
+		"userpass": {"username", "password"},
+	}
+
+	for _, attr := range required[authMethod] {
+		if d.NewValueKnown(attr) && d.Get(attr).(string) == "" {
+			return fmt.Errorf("%s is required when auth_method is %s", attr, authMethod)
+		}
+	}
+
+	return nil
+}
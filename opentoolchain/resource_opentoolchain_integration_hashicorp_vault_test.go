@@ -0,0 +1,24 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlattenHashicorpVaultIntegrationParams(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"name":                    "vault",
+		"server_url":              "https://vault.example.com:8200",
+		"path":                    "secret/data/toolchain",
+		"auth_method":             "approle",
+		"secret_reference_prefix": "{vault::vault.",
+	}, flattenHashicorpVaultIntegrationParams(map[string]interface{}{
+		"name":                  "vault",
+		"server_url":            "https://vault.example.com:8200",
+		"dashboard_url":         "",
+		"path":                  "secret/data/toolchain",
+		"authentication_method": "approle",
+		"role_id":               "encrypted-role",
+		"secret_id":             "encrypted-secret",
+	}))
+}
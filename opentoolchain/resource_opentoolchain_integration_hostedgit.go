@@ -0,0 +1,273 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+)
+
+const (
+	hostedGitIntegrationServiceType = "hostedgit"
+)
+
+func resourceOpenToolchainIntegrationHostedGit() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage IBM Git Repos and Issue Tracking integration (WARN: using undocumented APIs)",
+		CreateContext: resourceOpenToolchainIntegrationHostedGitCreate,
+		ReadContext:   resourceOpenToolchainIntegrationHostedGitRead,
+		DeleteContext: resourceOpenToolchainIntegrationHostedGitDelete,
+		UpdateContext: resourceOpenToolchainIntegrationHostedGitUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeGitRepoTypeDiff,
+		Schema: map[string]*schema.Schema{
+			"toolchain_id": {
+				Description: "The toolchain `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"integration_id": {
+				Description: "The integration `guid`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"repo_type": {
+				Description:  "Repository type: use `existing` repository, `clone` or create `new` repository",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "existing",
+				ValidateFunc: validation.StringInSlice([]string{"existing", "clone", "new"}, false),
+			},
+			"repo_url": {
+				Description:      "Repository url, for example: `https://us-south.git.cloud.ibm.com/<owner>/repository`, required if `repo_type` is `existing`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressGitSuffixDiff,
+			},
+			"source_repo_url": {
+				Description: "Source repository url, required if `repo_type` is `clone`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"repo_name": {
+				Description: "New repository name, required if `repo_type` is `clone` or `new`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"owner_id": {
+				Description: "User or group that owns new repository, used if `repo_type` is `clone` or `new`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"private": {
+				Description: "Repository visibility, set `true` if repository is private",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"enable_issues": {
+				Description: "Enable issue tracking",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"enable_traceability": {
+				Description: "Track deployment of code changes",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceOpenToolchainIntegrationHostedGitCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+	repoType := d.Get("repo_type").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
+
+	params := map[string]interface{}{
+		"git_id":              "hostedgit",
+		"authorized":          "hostedgit",
+		"type":                expandHostedGitRepoType(repoType),
+		"legal":               false,
+		"private_repo":        d.Get("private").(bool),
+		"has_issues":          d.Get("enable_issues").(bool),
+		"enable_traceability": d.Get("enable_traceability").(bool),
+	}
+
+	for _, k := range []string{"repo_url", "source_repo_url", "repo_name", "owner_id"} {
+		if v, ok := d.GetOk(k); ok {
+			params[k] = v.(string)
+		}
+	}
+
+	instanceID, err := createServiceInstanceWithID(ctx, c, toolchainID, envID, hostedGitIntegrationServiceType, params)
+
+	if err != nil {
+		return diag.Errorf("Error creating hosted Git integration: %s", err)
+	}
+
+	d.Set("integration_id", instanceID)
+	d.SetId(fmt.Sprintf("%s/%s/%s", instanceID, toolchainID, envID))
+
+	return resourceOpenToolchainIntegrationHostedGitRead(ctx, d, m)
+}
+
+func resourceOpenToolchainIntegrationHostedGitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Id()
+	idParts := strings.Split(id, "/")
+
+	if len(idParts) < 3 {
+		return diag.Errorf("Incorrect ID %s: ID should be a combination of integrationID/toolchainID/envID", d.Id())
+	}
+
+	integrationID := idParts[0]
+	toolchainID := idParts[1]
+	envID := idParts[2]
+
+	d.Set("integration_id", integrationID)
+	d.Set("toolchain_id", toolchainID)
+	d.Set("env_id", envID)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	svc, resp, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("[WARN] Hosted Git service instance '%s' is not found, removing it from state", integrationID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("Error reading hosted git service instance: %s", err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		for k, v := range flattenHostedGitIntegrationParams(svc.ServiceInstance.Parameters) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting hosted git integration %s: %s", k, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceOpenToolchainIntegrationHostedGitDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	integrationID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	_, err := c.DeleteServiceInstanceWithContext(ctx, &oc.DeleteServiceInstanceOptions{
+		GUID:        &integrationID,
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error deleting hosted Git integration: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceOpenToolchainIntegrationHostedGitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	if d.HasChange("private") || d.HasChange("enable_issues") || d.HasChange("enable_traceability") {
+		err := patchServiceInstance(ctx, c, toolchainID, envID, hostedGitIntegrationServiceType, instanceID, map[string]interface{}{
+			"private_repo":        d.Get("private").(bool),
+			"has_issues":          d.Get("enable_issues").(bool),
+			"enable_traceability": d.Get("enable_traceability").(bool),
+		})
+
+		if err != nil {
+			return diag.Errorf("Unable to update hosted Git integration: %s", err)
+		}
+	}
+
+	return resourceOpenToolchainIntegrationHostedGitRead(ctx, d, m)
+}
+
+// API uses `link` for existing repositories
+func expandHostedGitRepoType(repoType string) string {
+	if repoType == "existing" {
+		return "link"
+	}
+
+	return repoType
+}
+
+// flattenHostedGitIntegrationParams maps service instance parameters to hosted git integration attributes
+func flattenHostedGitIntegrationParams(params map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	if t, ok := params["type"].(string); ok && t != "" {
+		if t == "link" {
+			t = "existing"
+		}
+
+		result["repo_type"] = t
+	}
+
+	for _, k := range []string{"repo_url", "source_repo_url", "repo_name", "owner_id"} {
+		if v, ok := params[k].(string); ok && v != "" {
+			result[k] = v
+		}
+	}
+
+	for param, attr := range map[string]string{
+		"private_repo":        "private",
+		"has_issues":          "enable_issues",
+		"enable_traceability": "enable_traceability",
+	} {
+		if v, ok := params[param].(bool); ok {
+			result[attr] = v
+		}
+	}
+
+	return result
+}
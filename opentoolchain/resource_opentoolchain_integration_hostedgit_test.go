@@ -0,0 +1,26 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlattenHostedGitIntegrationParams(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"repo_type":           "existing",
+		"repo_url":            "https://us-south.git.cloud.ibm.com/owner/repo.git",
+		"private":             true,
+		"enable_issues":       true,
+		"enable_traceability": false,
+	}, flattenHostedGitIntegrationParams(map[string]interface{}{
+		"type":                "link",
+		"repo_url":            "https://us-south.git.cloud.ibm.com/owner/repo.git",
+		"private_repo":        true,
+		"has_issues":          true,
+		"enable_traceability": false,
+	}))
+
+	assert.Equal(t, "clone", flattenHostedGitIntegrationParams(map[string]interface{}{"type": "clone"})["repo_type"])
+	assert.Equal(t, "link", expandHostedGitRepoType("existing"))
+	assert.Equal(t, "new", expandHostedGitRepoType("new"))
+}
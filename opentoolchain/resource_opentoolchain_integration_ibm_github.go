@@ -44,19 +44,11 @@ func resourceOpenToolchainIntegrationIBMGithub() *schema.Resource {
 				Required:    true,
 			},
 			"repo_url": {
-				Description: "Github repository url",
-				Type:        schema.TypeString,
-				ForceNew:    true,
-				Required:    true,
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					if old == new {
-						return true
-					}
-					if strings.TrimSuffix(old, ".git") == strings.TrimSuffix(new, ".git") {
-						return true
-					}
-					return false
-				},
+				Description:      "Github repository url",
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				DiffSuppressFunc: suppressGitSuffixDiff,
 			},
 			"private": {
 				Description: "Set `true` if repository is private",
@@ -94,6 +86,9 @@ func resourceOpenToolchainIntegrationIBMGithubCreate(ctx context.Context, d *sch
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
+
 	integrationUUID := uuid.NewString()
 	uuidURL := fmt.Sprintf("%s/%s", repoURL, integrationUUID)
 
@@ -0,0 +1,66 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	jiraIntegrationServiceType = "jira"
+)
+
+var jiraIntegration = &serviceInstanceIntegration{
+	serviceType: jiraIntegrationServiceType,
+	title:       "Jira",
+	schema: map[string]*schema.Schema{
+		"server_url": {
+			Description:  "Jira Cloud or Jira Server URL, example: `https://example.atlassian.net`",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+		},
+		"project_key": {
+			Description: "Jira project key",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"user": {
+			Description: "Jira user email (Jira Cloud) or user name (Jira Server)",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"api_token": {
+			Description:  "Jira API token (Jira Cloud) or password (Jira Server), use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validateSecretReference,
+		},
+		"enable_traceability": {
+			Description: "Track deployment of code changes by creating labels and comments on Jira issues",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+	},
+	params: map[string]string{
+		"server_url":          "api_url",
+		"project_key":         "project_key",
+		"user":                "username",
+		"enable_traceability": "enable_traceability",
+	},
+	secrets: map[string]string{
+		"api_token": "password",
+	},
+	// only existing Jira projects are supported
+	extraParams: map[string]interface{}{
+		"type": "existing",
+	},
+	customizeDiff: []schema.CustomizeDiffFunc{
+		customizeSecretReferencesDiff("api_token", "", getSecretReferencesToolchainID),
+	},
+}
+
+func resourceOpenToolchainIntegrationJira() *schema.Resource {
+	return jiraIntegration.resource("Manage Jira integration, Rally issue tracker is not supported (WARN: using undocumented APIs)")
+}
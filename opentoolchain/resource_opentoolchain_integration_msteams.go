@@ -0,0 +1,45 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	msteamsIntegrationServiceType = "msteams"
+)
+
+var msteamsIntegration = &serviceInstanceIntegration{
+	serviceType: msteamsIntegrationServiceType,
+	title:       "Microsoft Teams",
+	schema: map[string]*schema.Schema{
+		"name": {
+			Description: "Integration name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"webhook_url": {
+			Description:  "Microsoft Teams incoming webhook URL, use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.",
+			Type:         schema.TypeString,
+			Required:     true,
+			Sensitive:    true,
+			ValidateFunc: validateSecretReference,
+		},
+		"events": notificationEventsSchema("Microsoft Teams"),
+	},
+	params: map[string]string{
+		"name": "name",
+	},
+	secrets: map[string]string{
+		"webhook_url": "api_token",
+	},
+	nameParam: "name",
+	expand:    expandNotificationEvents,
+	flatten:   flattenNotificationEvents,
+	customizeDiff: []schema.CustomizeDiffFunc{
+		customizeSecretReferencesDiff("webhook_url", "", getSecretReferencesToolchainID),
+	},
+}
+
+func resourceOpenToolchainIntegrationMSTeams() *schema.Resource {
+	return msteamsIntegration.resource("Manage Microsoft Teams integration (WARN: using undocumented APIs)")
+}
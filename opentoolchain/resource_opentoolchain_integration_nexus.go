@@ -0,0 +1,15 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	nexusIntegrationServiceType = "nexus"
+)
+
+var nexusIntegration = newArtifactRepositoryIntegration(nexusIntegrationServiceType, "Nexus", "server_url", []string{"maven", "npm"})
+
+func resourceOpenToolchainIntegrationNexus() *schema.Resource {
+	return nexusIntegration.resource("Manage Nexus integration (WARN: using undocumented APIs)")
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
-	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"regexp"
 	"strings"
 )
 
@@ -15,6 +16,8 @@ const (
 	pagerDutyIntegrationServiceType = "pagerduty"
 )
 
+var e164PhoneNumberRegexp = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
 func resourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manage PagerDuty integration (WARN: using undocumented APIs)",
@@ -25,6 +28,10 @@ func resourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeSecretHashDiff("api_key", "api_key_hash"),
+			customizeSecretReferencesDiff("api_key", "", getSecretReferencesToolchainID),
+		),
 		Schema: map[string]*schema.Schema{
 			"toolchain_id": {
 				Description: "The toolchain `guid`",
@@ -44,13 +51,20 @@ func resourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 				Required:    true,
 			},
 			"api_key": {
-				Description: "PagerDuty API key, use `{vault::vault_integration_name.API_KEY}` with vault integration.",
+				Description:  "PagerDuty API key, use `{vault::vault_integration_name.API_KEY}` with vault integration.",
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validateSecretReference,
+			},
+			"encrypted_api_key": {
+				Description: "Since API only provides encrypted API key value, we can use that internally to detect changes made outside of Terraform",
 				Type:        schema.TypeString,
-				Required:    true,
+				Computed:    true,
 				Sensitive:   true,
 			},
-			"encrypted_api_key": {
-				Description: "Since API only provides encrypted API key value, we can use that internally to track changes",
+			"api_key_hash": {
+				Description: "Salted hash of `api_key`, used internally to detect changes",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Sensitive:   true,
@@ -64,26 +78,35 @@ func resourceOpenToolchainIntegrationPagerDuty() *schema.Resource {
 				Description:   "Name of PagerDuty service URL",
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"service_name", "primary_email", "primary_phone_number"},
+				ConflictsWith: []string{"service_name", "primary_email", "primary_phone_number", "escalation_policy_id"},
 			},
 			"service_name": {
-				Description:   "Name of PagerDuty service to post alerts to",
+				Description:   "Name of PagerDuty service to post alerts to, service is created if it does not exist",
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"service_url"},
+				RequiredWith:  []string{"primary_email", "primary_phone_number"},
 			},
-			"primary_email": {
-				Description:   "The email address of the user to contact when alert is posted (required if `service_name` is specified)",
+			"escalation_policy_id": {
+				Description:   "ID of existing PagerDuty escalation policy used by the service, if not set, new policy is created for primary contact",
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"service_url"},
 			},
-			"primary_phone_number": {
-				Description:   "The phone number of the user to contact when alert is posted. If national code is omitted, `+1` is set by default (required if `service_name` is specified)",
+			"primary_email": {
+				Description:   "The email address of the user to contact when alert is posted",
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"service_url"},
 			},
+			"primary_phone_number": {
+				Description:      "The phone number of the user to contact when alert is posted, in E.164 format. If country code is omitted, `+1` is set by default",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"service_url"},
+				ValidateFunc:     validatePhoneNumber,
+				DiffSuppressFunc: suppressPhoneNumberDiff,
+			},
 		},
 	}
 }
@@ -92,116 +115,22 @@ func resourceOpenToolchainIntegrationPagerDutyCreate(ctx context.Context, d *sch
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
 	apiKey := d.Get("api_key").(string)
-	serviceName := d.Get("service_name").(string)
-	serviceURL := d.Get("service_url").(string)
-	primaryEmail := d.Get("primary_email").(string)
-	primaryPhoneNumber := d.Get("primary_phone_number").(string)
-
-	envIDParts := strings.Split(envID, ":")
-	region := envIDParts[len(envIDParts)-1]
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
-	integrationUUID := uuid.NewString()
-	// we don't want to use serviceName here, because this integration creates new service
-	// in pagerduty if it does not exist
-	uuidPrimaryEmail := fmt.Sprintf("%s/%s", primaryEmail, integrationUUID)
-	uuidServiceURL := fmt.Sprintf("%s/%s", serviceURL, integrationUUID)
-
-	keyType := "api"
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
 
-	if serviceURL != "" {
-		keyType = "service"
-	}
-
-	options := &oc.CreateServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		EnvID:       &envID,
-		ServiceID:   getStringPtr(pagerDutyIntegrationServiceType),
-		Parameters: &oc.CreateServiceInstanceParamsParameters{
-			KeyType:     &keyType,
-			ServiceName: &serviceName,
-			ServiceURL:  &serviceURL,
-			UserEmail:   &primaryEmail,
-			UserPhone:   &primaryPhoneNumber,
-		},
-	}
-
-	if keyType == "api" {
-		options.Parameters.APIKey = &apiKey
-		options.Parameters.UserEmail = &uuidPrimaryEmail // used to later locate the service
-	} else {
-		options.Parameters.ServiceKey = &apiKey
-		options.Parameters.ServiceURL = &uuidServiceURL // used to later locate the service
-	}
-
-	_, _, err := c.CreateServiceInstanceWithContext(ctx, options)
+	// new instance is located by comparing toolchain service instances before and after creation,
+	// user visible parameters (email, service URL) are never modified
+	integrationID, err := createServiceInstanceWithID(ctx, c, toolchainID, envID, pagerDutyIntegrationServiceType, expandPagerDutyIntegrationParams(d))
 
 	if err != nil {
-		return diag.Errorf("Error creating Slack integration: %s", err)
-	}
-
-	response, _, err := c.GetToolchainWithContext(ctx, &oc.GetToolchainOptions{
-		GUID:    &toolchainID,
-		Region:  &region,
-		Include: getStringPtr("fields,services"),
-	})
-
-	if err != nil {
-		return diag.Errorf("Error reading toolchain: %s", err)
-	}
-
-	if len(response.Items) == 0 {
-		return diag.Errorf("No toolchain found with GUID: %s", toolchainID)
-	}
-
-	toolchain := response.Items[0]
-
-	var integrationID string
-
-	// find new service instance
-	if toolchain.Services != nil {
-		for _, v := range toolchain.Services {
-			if v.ServiceID != nil && *v.ServiceID == pagerDutyIntegrationServiceType && v.Parameters != nil && (v.Parameters["user_email"] == uuidPrimaryEmail || v.Parameters["service_url"] == uuidServiceURL) && v.InstanceID != nil {
-				integrationID = *v.InstanceID
-				break
-			}
-		}
-	}
-
-	if integrationID == "" {
-		// no way to cleanup since we don't know slack integration GUID
-		return diag.Errorf("Unable to determine PagerDuty integration GUID")
-	}
-
-	_, err = c.PatchServiceInstanceWithContext(ctx, &oc.PatchServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		GUID:        &integrationID,
-		EnvID:       &envID,
-		ServiceID:   getStringPtr(pagerDutyIntegrationServiceType),
-		Parameters: &oc.PatchServiceInstanceParamsParameters{
-			APIKey:     &apiKey,
-			UserEmail:  &primaryEmail,
-			ServiceURL: &serviceURL,
-		},
-	})
-
-	if err != nil {
-		// try cleaning up
-		_, deleteErr := c.DeleteServiceInstanceWithContext(ctx, &oc.DeleteServiceInstanceOptions{
-			GUID:        &integrationID,
-			EnvID:       &envID,
-			ToolchainID: &toolchainID,
-		})
-
-		if deleteErr != nil {
-			return diag.Errorf("PagerDuty creation failed: %s, Unable to cleanup: %s", err, deleteErr)
-		}
-
-		return diag.Errorf("PagerDuty creation failed: %s", err)
+		return diag.Errorf("Error creating PagerDuty integration: %s", err)
 	}
 
+	d.Set("api_key_hash", hashSecret(apiKey))
 	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
 
 	return resourceOpenToolchainIntegrationPagerDutyRead(ctx, d, m)
@@ -246,14 +175,9 @@ func resourceOpenToolchainIntegrationPagerDutyRead(ctx context.Context, d *schem
 		params := svc.ServiceInstance.Parameters
 
 		if a, ok := params["api_key"]; ok {
-			newValue := a.(string)
-			currentValue := d.Get("encrypted_api_key").(string)
-
-			if currentValue != "" && currentValue != newValue {
-				d.Set("api_key", newValue) // force update
-			}
-
-			d.Set("encrypted_api_key", newValue)
+			readSecretDrift(d, "encrypted_api_key", "api_key_hash", a.(string))
+		} else if a, ok := params["service_key"]; ok {
+			readSecretDrift(d, "encrypted_api_key", "api_key_hash", a.(string))
 		}
 
 		if s, ok := params["service_name"]; ok {
@@ -275,6 +199,10 @@ func resourceOpenToolchainIntegrationPagerDutyRead(ctx context.Context, d *schem
 		if p, ok := params["user_phone"]; ok {
 			d.Set("primary_phone_number", p.(string))
 		}
+
+		if p, ok := params["escalation_policy_id"].(string); ok {
+			d.Set("escalation_policy_id", p)
+		}
 	}
 
 	return nil
@@ -307,53 +235,80 @@ func resourceOpenToolchainIntegrationPagerDutyUpdate(ctx context.Context, d *sch
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
 	apiKey := d.Get("api_key").(string)
-	serviceName := d.Get("service_name").(string)
-	serviceURL := d.Get("service_url").(string)
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
-	keyType := "api"
+	if d.HasChanges("primary_email", "primary_phone_number", "service_name", "service_url", "escalation_policy_id") || secretChanged(d, "api_key", "api_key_hash") {
+		err := patchServiceInstance(ctx, c, toolchainID, envID, pagerDutyIntegrationServiceType, instanceID, expandPagerDutyIntegrationParams(d))
 
-	if serviceURL != "" {
-		keyType = "service"
+		if err != nil {
+			return diag.Errorf("Unable to update PagerDuty integration: %s", err)
+		}
+
+		// API key is always sent, API re-encrypts it
+		d.Set("encrypted_api_key", "")
+		d.Set("api_key_hash", hashSecret(apiKey))
 	}
 
-	options := &oc.PatchServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		EnvID:       &envID,
-		GUID:        &instanceID,
-		ServiceID:   getStringPtr(pagerDutyIntegrationServiceType),
-		Parameters: &oc.PatchServiceInstanceParamsParameters{
-			ServiceName: &serviceName,
-			ServiceURL:  &serviceURL,
-			KeyType:     &keyType,
-		},
+	return resourceOpenToolchainIntegrationPagerDutyRead(ctx, d, m)
+}
+
+func expandPagerDutyIntegrationParams(d *schema.ResourceData) map[string]interface{} {
+	apiKey := d.Get("api_key").(string)
+	serviceURL := d.Get("service_url").(string)
+
+	params := map[string]interface{}{
+		"service_name":         d.Get("service_name").(string),
+		"service_url":          serviceURL,
+		"user_email":           d.Get("primary_email").(string),
+		"escalation_policy_id": d.Get("escalation_policy_id").(string),
 	}
 
-	if keyType == "api" {
-		options.Parameters.APIKey = &apiKey
+	if phone := d.Get("primary_phone_number").(string); phone != "" {
+		params["user_phone"] = normalizePhoneNumber(phone)
 	} else {
-		options.Parameters.ServiceKey = &apiKey
+		params["user_phone"] = ""
 	}
 
-	if d.HasChange("primary_email") {
-		primaryEmail := d.Get("primary_email").(string)
-		options.Parameters.UserEmail = &primaryEmail
+	if serviceURL != "" {
+		params["key_type"] = "service"
+		params["service_key"] = apiKey
+	} else {
+		params["key_type"] = "api"
+		params["api_key"] = apiKey
 	}
 
-	if d.HasChange("primary_phone_number") {
-		primaryPhoneNumber := d.Get("primary_phone_number").(string)
-		options.Parameters.UserPhone = &primaryPhoneNumber
+	return params
+}
+
+var phoneNumberSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+// normalizePhoneNumber removes separators and adds `+1` country code if it is missing
+func normalizePhoneNumber(phone string) string {
+	phone = phoneNumberSeparators.Replace(strings.TrimSpace(phone))
+
+	if phone != "" && !strings.HasPrefix(phone, "+") {
+		phone = "+1" + phone
 	}
 
-	if d.HasChange("primary_email") || d.HasChange("primary_phone_number") || d.HasChange("api_key") || d.HasChange("service_name") || d.HasChange("service_url") {
-		_, err := c.PatchServiceInstanceWithContext(ctx, options)
+	return phone
+}
 
-		if err != nil {
-			return diag.Errorf("Unable to update PagerDuty integration: %s", err)
-		}
+func validatePhoneNumber(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
 	}
 
-	return resourceOpenToolchainIntegrationPagerDutyRead(ctx, d, m)
+	if !e164PhoneNumberRegexp.MatchString(normalizePhoneNumber(v)) {
+		return nil, []error{fmt.Errorf("expected %s to be a phone number in E.164 format, for example: +15551234567, got: %s", k, v)}
+	}
+
+	return nil, nil
+}
+
+func suppressPhoneNumberDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizePhoneNumber(old) == normalizePhoneNumber(new)
 }
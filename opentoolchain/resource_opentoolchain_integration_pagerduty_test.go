@@ -0,0 +1,58 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	assert.Equal(t, "+15551234567", normalizePhoneNumber("555-123-4567"))
+	assert.Equal(t, "+15551234567", normalizePhoneNumber("(555) 123 4567"))
+	assert.Equal(t, "+442071234567", normalizePhoneNumber("+44 20 7123 4567"))
+	assert.Equal(t, "", normalizePhoneNumber(""))
+}
+
+func TestValidatePhoneNumber(t *testing.T) {
+	_, errs := validatePhoneNumber("555.123.4567", "primary_phone_number")
+	assert.Empty(t, errs)
+
+	_, errs = validatePhoneNumber("+44 20 7123 4567", "primary_phone_number")
+	assert.Empty(t, errs)
+
+	_, errs = validatePhoneNumber("call me", "primary_phone_number")
+	assert.Len(t, errs, 1)
+
+	_, errs = validatePhoneNumber("+0123", "primary_phone_number")
+	assert.Len(t, errs, 1)
+
+	_, errs = validatePhoneNumber("1234567890123456", "primary_phone_number")
+	assert.Len(t, errs, 1)
+}
+
+func TestExpandPagerDutyIntegrationParams(t *testing.T) {
+	d := resourceOpenToolchainIntegrationPagerDuty().TestResourceData()
+	d.Set("api_key", "key")
+	d.Set("service_name", "alerts")
+	d.Set("primary_email", "oncall@example.com")
+	d.Set("primary_phone_number", "555 123 4567")
+
+	assert.Equal(t, map[string]interface{}{
+		"key_type":             "api",
+		"api_key":              "key",
+		"service_name":         "alerts",
+		"service_url":          "",
+		"user_email":           "oncall@example.com",
+		"user_phone":           "+15551234567",
+		"escalation_policy_id": "",
+	}, expandPagerDutyIntegrationParams(d))
+
+	d = resourceOpenToolchainIntegrationPagerDuty().TestResourceData()
+	d.Set("api_key", "key")
+	d.Set("service_url", "https://example.pagerduty.com/services/PXXXXXX")
+
+	params := expandPagerDutyIntegrationParams(d)
+
+	assert.Equal(t, "service", params["key_type"])
+	assert.Equal(t, "key", params["service_key"])
+	assert.NotContains(t, params, "api_key")
+}
@@ -0,0 +1,61 @@
+package opentoolchain
+
+import (
+	"context"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	privateWorkerIntegrationServiceType = "private_worker"
+)
+
+var privateWorkerIntegration = &serviceInstanceIntegration{
+	serviceType: privateWorkerIntegrationServiceType,
+	title:       "Private Worker",
+	schema: map[string]*schema.Schema{
+		"name": {
+			Description: "Integration name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"worker_queue": {
+			Description: "Worker queue identifier, computed by the service if not set",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		"api_key": {
+			Description:  "Service ID API key used by private worker to access the worker queue, use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.",
+			Type:         schema.TypeString,
+			Required:     true,
+			Sensitive:    true,
+			ValidateFunc: validateSecretReference,
+		},
+		"worker_id": {
+			Description: "Private worker ID, can be used as tekton pipeline `worker_id`",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	},
+	params: map[string]string{
+		"name":         "name",
+		"worker_queue": "workerQueueIdentifier",
+	},
+	secrets: map[string]string{
+		"api_key": "workerQueueCredentials",
+	},
+	nameParam: "name",
+	readExtra: func(ctx context.Context, config *ProviderConfig, d *schema.ResourceData, svc *oc.GetServiceInstanceResponseServiceInstance, integrationID string, toolchainID string, envID string) error {
+		// pipelines reference private workers by service instance GUID
+		d.Set("worker_id", integrationID)
+		return nil
+	},
+	customizeDiff: []schema.CustomizeDiffFunc{
+		customizeSecretReferencesDiff("api_key", "", getSecretReferencesToolchainID),
+	},
+}
+
+func resourceOpenToolchainIntegrationPrivateWorker() *schema.Resource {
+	return privateWorkerIntegration.resource("Manage Private Worker integration (WARN: using undocumented APIs)")
+}
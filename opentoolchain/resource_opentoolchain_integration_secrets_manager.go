@@ -0,0 +1,101 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	secretsManagerIntegrationServiceType = "secretsmanager"
+)
+
+var secretsManagerIntegration = newSecretsManagerIntegration()
+
+func newSecretsManagerIntegration() *serviceInstanceIntegration {
+	r := newCloudInstanceIntegration(secretsManagerIntegrationServiceType, "Secrets Manager")
+
+	r.schema["name"].Description = "Integration name, used in secret references: `{vault::integration_name.SECRET_NAME}`"
+
+	r.schema["secret_group"] = &schema.Schema{
+		Description: "Secrets Manager secret group ID, limits secrets available to the toolchain",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+
+	r.schema["auth_type"] = &schema.Schema{
+		Description:  "Authentication type: `iam` uses toolchain IAM service authorization, `service_id` uses service ID",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "iam",
+		ValidateFunc: validation.StringInSlice([]string{"iam", "service_id"}, false),
+	}
+
+	r.schema["service_id"] = &schema.Schema{
+		Description: "IAM service ID that has access to Secrets Manager instance, required if `auth_type` is `service_id`",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+
+	r.schema["secret_reference_prefix"] = &schema.Schema{
+		Description: "Prefix for secret references, for example: `\"${opentoolchain_integration_secrets_manager.sm.secret_reference_prefix}SECRET_NAME}\"`",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+
+	r.params["auth_type"] = "auth-type"
+	r.expand = expandSecretsManagerIntegrationParams
+	r.flatten = flattenSecretsManagerIntegrationParams
+	r.customizeDiff = []schema.CustomizeDiffFunc{
+		customizeSecretsManagerAuthDiff,
+	}
+
+	return r
+}
+
+func resourceOpenToolchainIntegrationSecretsManager() *schema.Resource {
+	return secretsManagerIntegration.resource("Manage IBM Secrets Manager integration (WARN: using undocumented APIs)")
+}
+
+// expandSecretsManagerIntegrationParams adds instance reference and optional parameters, empty values are not sent
+func expandSecretsManagerIntegrationParams(d *schema.ResourceData, params map[string]interface{}) {
+	expandCloudInstanceParams(d, params)
+
+	if g, ok := d.GetOk("secret_group"); ok {
+		params["secrets-group"] = g.(string)
+	}
+
+	if s, ok := d.GetOk("service_id"); ok {
+		params["service-id"] = s.(string)
+	}
+}
+
+func flattenSecretsManagerIntegrationParams(params map[string]interface{}, result map[string]interface{}) {
+	flattenCloudInstanceParams(params, result)
+
+	for param, attr := range map[string]string{
+		"secrets-group": "secret_group",
+		"service-id":    "service_id",
+	} {
+		if v, ok := params[param].(string); ok && v != "" {
+			result[attr] = v
+		}
+	}
+
+	if name, ok := result["name"].(string); ok {
+		result["secret_reference_prefix"] = getSecretReferencePrefix(name)
+	}
+}
+
+func customizeSecretsManagerAuthDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("auth_type") || !d.NewValueKnown("service_id") {
+		return nil
+	}
+
+	if d.Get("auth_type").(string) == "service_id" && d.Get("service_id").(string) == "" {
+		return fmt.Errorf("service_id is required when auth_type is service_id")
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package opentoolchain
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFlattenSecretsManagerIntegrationParams(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"name":                    "sm-vault",
+		"instance_crn":            "crn:v1:bluemix:public:secrets-manager:us-south:a/123:456::",
+		"auth_type":               "iam",
+		"secret_reference_prefix": "{vault::sm-vault.",
+	}, secretsManagerIntegration.flattenParams(map[string]interface{}{
+		"name":             "sm-vault",
+		"instance-id-type": "instance-crn",
+		"instance-crn":     "crn:v1:bluemix:public:secrets-manager:us-south:a/123:456::",
+		"instance-name":    "",
+		"auth-type":        "iam",
+	}))
+}
+
+func TestExpandSecretsManagerIntegrationParams(t *testing.T) {
+	d := resourceOpenToolchainIntegrationSecretsManager().TestResourceData()
+	d.Set("name", "sm-vault")
+	d.Set("auth_type", "iam")
+	d.Set("instance_crn", "crn:v1:bluemix:public:secrets-manager:us-south:a/123:456::")
+
+	assert.Equal(t, map[string]interface{}{
+		"name":             "sm-vault",
+		"auth-type":        "iam",
+		"instance-id-type": "instance-crn",
+		"instance-crn":     "crn:v1:bluemix:public:secrets-manager:us-south:a/123:456::",
+	}, secretsManagerIntegration.expandParams(d))
+}
+
+func TestCustomizeSecretsManagerAuthDiff(t *testing.T) {
+	r := resourceOpenToolchainIntegrationSecretsManager()
+
+	config := map[string]interface{}{
+		"toolchain_id": "toolchain",
+		"env_id":       "ibm:yp:us-south",
+		"name":         "sm-vault",
+		"instance_crn": "crn:v1:bluemix:public:secrets-manager:us-south:a/123:456::",
+		"auth_type":    "service_id",
+	}
+
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "service_id is required when auth_type is service_id")
+	}
+
+	config["service_id"] = "ServiceId-123"
+
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	assert.NoError(t, err)
+}
@@ -1,13 +1,19 @@
 package opentoolchain
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
-	"github.com/google/uuid"
+	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"io"
+	"io/ioutil"
 	"log"
+	"net/http"
 	"strings"
 )
 
@@ -25,6 +31,10 @@ func resourceOpenToolchainIntegrationSlack() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeSecretHashDiff("webhook_url", "webhook_url_hash"),
+			customizeSecretReferencesDiff("webhook_url", "", getSecretReferencesToolchainID),
+		),
 		Schema: map[string]*schema.Schema{
 			"toolchain_id": {
 				Description: "The toolchain `guid`",
@@ -44,13 +54,20 @@ func resourceOpenToolchainIntegrationSlack() *schema.Resource {
 				Required:    true,
 			},
 			"webhook_url": {
-				Description: "Slack webhook URL, use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.",
+				Description:  "Slack webhook URL, use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.",
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validateSecretReference,
+			},
+			"encrypted_webhook_url": {
+				Description: "Since API only provides encrypted webook URL value, we can use that internally to detect changes made outside of Terraform",
 				Type:        schema.TypeString,
-				Required:    true,
+				Computed:    true,
 				Sensitive:   true,
 			},
-			"encrypted_webhook_url": {
-				Description: "Since API only provides encrypted webook URL value, we can use that internally to track changes",
+			"webhook_url_hash": {
+				Description: "Salted hash of `webhook_url`, used internally to detect changes",
 				Type:        schema.TypeString,
 				Computed:    true,
 				Sensitive:   true,
@@ -65,44 +82,15 @@ func resourceOpenToolchainIntegrationSlack() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
-			"events": {
-				Description: "Events for which you want to receive notifications",
-				Type:        schema.TypeList,
-				MaxItems:    1,
+			"events": notificationEventsSchema("slack"),
+			"send_test_message": {
+				Description: "Post a test message to `webhook_url` before creating the integration, to make sure the webhook works. Only applies at create time, changing it later has no effect. Skipped if `webhook_url` is a secret reference",
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"pipeline_start": {
-							Description: "Send slack notification when pipeline is started",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-						},
-						"pipeline_success": {
-							Description: "Send slack notification when pipeline succeeds",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-						},
-						"pipeline_fail": {
-							Description: "Send slack notification when pipeline fails",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-						},
-						"toolchain_bind": {
-							Description: "Send slack notification when integration is created",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-						},
-						"toolchain_unbind": {
-							Description: "Send slack notification when integration is removed",
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Default:     true,
-						},
-					},
+				Default:     false,
+				// test message is only sent on create, there is nothing to update afterwards
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
 				},
 			},
 		},
@@ -112,110 +100,29 @@ func resourceOpenToolchainIntegrationSlack() *schema.Resource {
 func resourceOpenToolchainIntegrationSlackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	envID := d.Get("env_id").(string)
 	toolchainID := d.Get("toolchain_id").(string)
-	teamName := d.Get("team_name").(string)
-	channelName := d.Get("channel_name").(string)
 	webhookURL := d.Get("webhook_url").(string)
-	evt, evtOK := d.GetOk("events")
 
-	envIDParts := strings.Split(envID, ":")
-	region := envIDParts[len(envIDParts)-1]
-
-	events := map[string]bool{
-		"pipeline_start":   true,
-		"pipeline_success": true,
-		"pipeline_fail":    true,
-		"toolchain_bind":   true,
-		"toolchain_unbind": true,
-	}
-
-	if evtOK {
-		e := evt.([]interface{})[0].(map[string]interface{})
-
-		events = map[string]bool{
-			"pipeline_start":   e["pipeline_start"].(bool),
-			"pipeline_success": e["pipeline_success"].(bool),
-			"pipeline_fail":    e["pipeline_fail"].(bool),
-			"toolchain_bind":   e["toolchain_bind"].(bool),
-			"toolchain_unbind": e["toolchain_unbind"].(bool),
+	if d.Get("send_test_message").(bool) {
+		if ref, _ := parseSecretReference(webhookURL); ref != nil {
+			log.Printf("[WARN] Slack webhook URL is a secret reference, skipping test message")
+		} else if err := sendSlackTestMessage(ctx, webhookURL, toolchainID); err != nil {
+			return diag.Errorf("Error sending Slack test message: %s", err)
 		}
 	}
 
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
-	integrationUUID := uuid.NewString()
-	uuidChannelName := fmt.Sprintf("%s/%s", channelName, integrationUUID)
-
-	options := &oc.CreateServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		EnvID:       &envID,
-		ServiceID:   getStringPtr(slackIntegrationServiceType),
-		Parameters: &oc.CreateServiceInstanceParamsParameters{
-			ChannelName:     &uuidChannelName,
-			TeamURL:         &teamName,
-			APIToken:        &webhookURL,
-			PipelineStart:   getBoolPtr(events["pipeline_start"]),
-			PipelineSuccess: getBoolPtr(events["pipeline_success"]),
-			PipelineFail:    getBoolPtr(events["pipeline_fail"]),
-			ToolchainBind:   getBoolPtr(events["toolchain_bind"]),
-			ToolchainUnbind: getBoolPtr(events["toolchain_unbind"]),
-		},
-	}
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
 
-	_, _, err := c.CreateServiceInstanceWithContext(ctx, options)
+	integrationID, err := createServiceInstanceWithID(ctx, c, toolchainID, envID, slackIntegrationServiceType, expandSlackIntegrationParams(d))
 
 	if err != nil {
 		return diag.Errorf("Error creating Slack integration: %s", err)
 	}
 
-	response, _, err := c.GetToolchainWithContext(ctx, &oc.GetToolchainOptions{
-		GUID:    &toolchainID,
-		Region:  &region,
-		Include: getStringPtr("fields,services"),
-	})
-
-	if err != nil {
-		return diag.Errorf("Error reading toolchain: %s", err)
-	}
-
-	if len(response.Items) == 0 {
-		return diag.Errorf("No toolchain found with GUID: %s", toolchainID)
-	}
-
-	toolchain := response.Items[0]
-
-	var integrationID string
-
-	// find new service instance
-	if toolchain.Services != nil {
-		for _, v := range toolchain.Services {
-			if v.ServiceID != nil && *v.ServiceID == slackIntegrationServiceType && v.Parameters != nil && v.Parameters["channel_name"] == uuidChannelName && v.InstanceID != nil {
-				integrationID = *v.InstanceID
-				break
-			}
-		}
-	}
-
-	if integrationID == "" {
-		// no way to cleanup since we don't know slack integration GUID
-		return diag.Errorf("Unable to determine Slack integration GUID")
-	}
-
-	_, err = c.PatchServiceInstanceWithContext(ctx, &oc.PatchServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		GUID:        &integrationID,
-		EnvID:       &envID,
-		ServiceID:   getStringPtr(slackIntegrationServiceType),
-		Parameters: &oc.PatchServiceInstanceParamsParameters{
-			APIToken:    &webhookURL,
-			ChannelName: &channelName,
-		},
-	})
-
-	if err != nil {
-		return diag.Errorf("Unable to update Slack channel name: %s", err)
-	}
-
+	d.Set("webhook_url_hash", hashSecret(webhookURL))
 	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
 
 	return resourceOpenToolchainIntegrationSlackRead(ctx, d, m)
@@ -258,35 +165,15 @@ func resourceOpenToolchainIntegrationSlackRead(ctx context.Context, d *schema.Re
 
 	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
 		params := svc.ServiceInstance.Parameters
-		events := []interface{}{map[string]bool{
-			"pipeline_start":   params["pipeline_start"].(bool),
-			"pipeline_success": params["pipeline_success"].(bool),
-			"pipeline_fail":    params["pipeline_fail"].(bool),
-			"toolchain_bind":   params["toolchain_bind"].(bool),
-			"toolchain_unbind": params["toolchain_unbind"].(bool),
-		}}
-
-		if err := d.Set("events", events); err != nil {
-			return diag.Errorf("Error setting slack integration events: %s", err)
-		}
 
-		if w, ok := params["api_token"]; ok {
-			newValue := w.(string)
-			currentValue := d.Get("encrypted_webhook_url").(string)
-
-			if currentValue != "" && currentValue != newValue {
-				d.Set("webhook_url", newValue) // force update
+		for k, v := range flattenSlackIntegrationParams(params) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting slack integration %s: %s", k, err)
 			}
-
-			d.Set("encrypted_webhook_url", newValue)
 		}
 
-		if n, ok := params["channel_name"]; ok {
-			d.Set("channel_name", n.(string))
-		}
-
-		if t, ok := params["team_url"]; ok {
-			d.Set("team_name", t.(string))
+		if w, ok := params["api_token"].(string); ok {
+			readSecretDrift(d, "encrypted_webhook_url", "webhook_url_hash", w)
 		}
 	}
 
@@ -324,44 +211,81 @@ func resourceOpenToolchainIntegrationSlackUpdate(ctx context.Context, d *schema.
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
-	options := &oc.PatchServiceInstanceOptions{
-		ToolchainID: &toolchainID,
-		EnvID:       &envID,
-		GUID:        &instanceID,
-		ServiceID:   getStringPtr(slackIntegrationServiceType),
-		Parameters: &oc.PatchServiceInstanceParamsParameters{
-			APIToken: &webhookURL, // seems to be mandatory for patching
-		},
+	if d.HasChange("channel_name") || d.HasChange("team_name") || d.HasChange("events") || secretChanged(d, "webhook_url", "webhook_url_hash") {
+		// all parameters are sent, so that events missing on older integrations are set as well,
+		// webhook URL seems to be mandatory for patching
+		err := patchServiceInstance(ctx, c, toolchainID, envID, slackIntegrationServiceType, instanceID, expandSlackIntegrationParams(d))
+
+		if err != nil {
+			return diag.Errorf("Unable to update Slack integration: %s", err)
+		}
+
+		// webhook URL is always sent, API re-encrypts it
+		d.Set("encrypted_webhook_url", "")
+		d.Set("webhook_url_hash", hashSecret(webhookURL))
+	}
+
+	return resourceOpenToolchainIntegrationSlackRead(ctx, d, m)
+}
+
+// expandSlackIntegrationParams returns slack service instance parameters, all events are included
+func expandSlackIntegrationParams(d *schema.ResourceData) map[string]interface{} {
+	params := map[string]interface{}{
+		"channel_name": d.Get("channel_name").(string),
+		"team_url":     d.Get("team_name").(string),
+		"api_token":    d.Get("webhook_url").(string),
+	}
+
+	expandNotificationEvents(d, params)
+	return params
+}
+
+// flattenSlackIntegrationParams maps slack service instance parameters to attributes shared by resource and data source
+func flattenSlackIntegrationParams(params map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	if n, ok := params["channel_name"].(string); ok {
+		result["channel_name"] = n
 	}
 
-	if d.HasChange("channel_name") {
-		channelName := d.Get("channel_name").(string)
-		options.Parameters.ChannelName = &channelName
+	if t, ok := params["team_url"].(string); ok {
+		result["team_name"] = t
 	}
 
-	if d.HasChange("team_name") {
-		teamName := d.Get("team_name").(string)
-		options.Parameters.TeamURL = &teamName
+	flattenNotificationEvents(params, result)
+	return result
+}
+
+// sendSlackTestMessage posts a message to slack incoming webhook, to make sure it is valid before integration is created
+func sendSlackTestMessage(ctx context.Context, webhookURL string, toolchainID string) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("Slack integration is being configured for toolchain %s", toolchainID),
+	})
+
+	if err != nil {
+		return err
 	}
 
-	if d.HasChange("events") {
-		evt := d.Get("events").([]interface{})
-		events := evt[0].(map[string]interface{})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
 
-		options.Parameters.PipelineStart = getBoolPtr(events["pipeline_start"].(bool))
-		options.Parameters.PipelineSuccess = getBoolPtr(events["pipeline_success"].(bool))
-		options.Parameters.PipelineFail = getBoolPtr(events["pipeline_fail"].(bool))
-		options.Parameters.ToolchainBind = getBoolPtr(events["toolchain_bind"].(bool))
-		options.Parameters.ToolchainUnbind = getBoolPtr(events["toolchain_unbind"].(bool))
+	if err != nil {
+		return err
 	}
 
-	if d.HasChange("channel_name") || d.HasChange("team_name") || d.HasChange("events") || d.HasChange("events") || d.HasChange("webhook_url") {
-		_, err := c.PatchServiceInstanceWithContext(ctx, options)
+	req.Header.Set("Content-Type", "application/json")
 
-		if err != nil {
-			return diag.Errorf("Unable to update Slack integration: %s", err)
-		}
+	resp, err := cleanhttp.DefaultClient().Do(req)
+
+	if err != nil {
+		return err
 	}
 
-	return resourceOpenToolchainIntegrationSlackRead(ctx, d, m)
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
 }
@@ -0,0 +1,108 @@
+package opentoolchain
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExpandSlackIntegrationParams(t *testing.T) {
+	d := resourceOpenToolchainIntegrationSlack().TestResourceData()
+	d.Set("channel_name", "builds")
+	d.Set("team_name", "team")
+	d.Set("webhook_url", "https://hooks.slack.com/services/T/B/X")
+	d.Set("events", []interface{}{map[string]interface{}{
+		"pipeline_start":     false,
+		"pipeline_success":   true,
+		"pipeline_fail":      true,
+		"toolchain_bind":     true,
+		"toolchain_unbind":   true,
+		"pipeline_task_fail": true,
+		"toolchain_update":   false,
+	}})
+
+	assert.Equal(t, map[string]interface{}{
+		"channel_name":       "builds",
+		"team_url":           "team",
+		"api_token":          "https://hooks.slack.com/services/T/B/X",
+		"pipeline_start":     false,
+		"pipeline_success":   true,
+		"pipeline_fail":      true,
+		"toolchain_bind":     true,
+		"toolchain_unbind":   true,
+		"pipeline_task_fail": true,
+		"toolchain_update":   false,
+	}, expandSlackIntegrationParams(d))
+}
+
+func TestFlattenSlackIntegrationParams(t *testing.T) {
+	result := flattenSlackIntegrationParams(map[string]interface{}{
+		"channel_name":     "builds",
+		"team_url":         "team",
+		"api_token":        "encrypted",
+		"pipeline_start":   true,
+		"pipeline_success": false,
+		"pipeline_fail":    true,
+		"toolchain_bind":   false,
+		"toolchain_unbind": true,
+		"toolchain_update": true,
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"channel_name": "builds",
+		"team_name":    "team",
+		"events": []interface{}{map[string]interface{}{
+			"pipeline_start":     true,
+			"pipeline_success":   false,
+			"pipeline_fail":      true,
+			"toolchain_bind":     false,
+			"toolchain_unbind":   true,
+			"pipeline_task_fail": false,
+			"toolchain_update":   true,
+		}},
+	}, result)
+
+	// events are not set if integration parameters are incomplete
+	result = flattenSlackIntegrationParams(map[string]interface{}{
+		"channel_name": "builds",
+	})
+
+	assert.Equal(t, map[string]interface{}{"channel_name": "builds"}, result)
+}
+
+func TestSendSlackTestMessage(t *testing.T) {
+	var body map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	assert.NoError(t, sendSlackTestMessage(context.Background(), server.URL, "toolchain-guid"))
+	assert.Contains(t, body["text"], "toolchain-guid")
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("invalid_token"))
+	}))
+	defer failing.Close()
+
+	err := sendSlackTestMessage(context.Background(), failing.URL, "toolchain-guid")
+	assert.EqualError(t, err, "webhook responded with status 403: invalid_token")
+}
+
+func TestSlackSendTestMessageDiffSuppress(t *testing.T) {
+	r := resourceOpenToolchainIntegrationSlack()
+	suppress := r.Schema["send_test_message"].DiffSuppressFunc
+
+	d := r.TestResourceData()
+	assert.False(t, suppress("send_test_message", "false", "true", d))
+
+	d.SetId("integration/toolchain/ibm:yp:us-south")
+	assert.True(t, suppress("send_test_message", "false", "true", d))
+}
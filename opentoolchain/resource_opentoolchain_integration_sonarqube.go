@@ -0,0 +1,63 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	sonarqubeIntegrationServiceType = "sonarqube"
+)
+
+var sonarqubeIntegration = &serviceInstanceIntegration{
+	serviceType: sonarqubeIntegrationServiceType,
+	title:       "SonarQube",
+	schema: map[string]*schema.Schema{
+		"name": {
+			Description: "Integration name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"server_url": {
+			Description:  "SonarQube server URL",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+		},
+		"user_login": {
+			Description: "SonarQube user name, leave empty when using `token`",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"token": {
+			Description:  "SonarQube password or authentication token, use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validateSecretReference,
+		},
+		"blind_connection": {
+			Description: "Set to `true` if SonarQube server is not reachable from IBM Cloud, toolchain will not validate the connection",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+	},
+	params: map[string]string{
+		"name":             "name",
+		"server_url":       "dashboard_url",
+		"user_login":       "user_login",
+		"blind_connection": "blind_connection",
+	},
+	secrets: map[string]string{
+		"token": "user_password",
+	},
+	nameParam: "name",
+	customizeDiff: []schema.CustomizeDiffFunc{
+		customizeSecretReferencesDiff("token", "", getSecretReferencesToolchainID),
+	},
+}
+
+func resourceOpenToolchainIntegrationSonarQube() *schema.Resource {
+	return sonarqubeIntegration.resource("Manage SonarQube integration (WARN: using undocumented APIs)")
+}
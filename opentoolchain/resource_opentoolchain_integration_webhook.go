@@ -0,0 +1,45 @@
+package opentoolchain
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	webhookIntegrationServiceType = "webhook"
+)
+
+var webhookIntegration = &serviceInstanceIntegration{
+	serviceType: webhookIntegrationServiceType,
+	title:       "Webhook",
+	schema: map[string]*schema.Schema{
+		"name": {
+			Description: "Integration name",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"webhook_url": {
+			Description:  "Webhook URL, toolchain events are sent as JSON payload using POST requests, use `{vault::vault_integration_name.VAULT_KEY}` with vault integration.",
+			Type:         schema.TypeString,
+			Required:     true,
+			Sensitive:    true,
+			ValidateFunc: validateSecretReference,
+		},
+		"events": notificationEventsSchema("Webhook"),
+	},
+	params: map[string]string{
+		"name": "name",
+	},
+	secrets: map[string]string{
+		"webhook_url": "api_token",
+	},
+	nameParam: "name",
+	expand:    expandNotificationEvents,
+	flatten:   flattenNotificationEvents,
+	customizeDiff: []schema.CustomizeDiffFunc{
+		customizeSecretReferencesDiff("webhook_url", "", getSecretReferencesToolchainID),
+	},
+}
+
+func resourceOpenToolchainIntegrationWebhook() *schema.Resource {
+	return webhookIntegration.resource("Manage Webhook integration (WARN: using undocumented APIs)")
+}
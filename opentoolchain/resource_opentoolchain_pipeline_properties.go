@@ -19,6 +19,7 @@ func resourceOpenToolchainPipelineProperties() *schema.Resource {
 		ReadContext:        resourceOpenToolchainPipelinePropertiesRead,
 		DeleteContext:      resourceOpenToolchainPipelinePropertiesDelete,
 		UpdateContext:      resourceOpenToolchainPipelinePropertiesUpdate,
+		CustomizeDiff:      customizeSecretMapHashDiff("secret_env", "secret_hashes"),
 		Schema: map[string]*schema.Schema{
 			"guid": {
 				Description: "The tekton pipeline `guid`",
@@ -102,9 +103,18 @@ func resourceOpenToolchainPipelineProperties() *schema.Resource {
 				Sensitive: true,
 				Computed:  true,
 			},
+			"secret_hashes": {
+				Type:        schema.TypeMap,
+				Description: "Salted hashes of `secret_env` values, used internally to detect changes",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Sensitive: true,
+				Computed:  true,
+			},
 			"encrypted_secrets": {
 				Type:        schema.TypeMap,
-				Description: "Opentoolchain API does not return actual secret values, this is used internally to track changes to encrypted strings",
+				Description: "Opentoolchain API does not return actual secret values, encrypted strings are used internally to detect changes made outside of Terraform",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -144,7 +154,6 @@ func resourceOpenToolchainPipelinePropertiesRead(ctx context.Context, d *schema.
 	}
 
 	textEnv := getEnvMap(pipeline.EnvProperties, "TEXT")
-	secretEnv := getEnvMap(pipeline.EnvProperties, "SECURE")
 
 	if env, ok := d.GetOk("text_env"); ok {
 		envMap := env.(map[string]interface{})
@@ -159,29 +168,9 @@ func resourceOpenToolchainPipelinePropertiesRead(ctx context.Context, d *schema.
 		d.Set("text_env", envMap)
 	}
 
-	if env, ok := d.GetOk("secret_env"); ok {
-		encryptedSecrets := d.Get("encrypted_secrets")
-		envMap := env.(map[string]interface{})
-		for k := range envMap {
-			if newVal, ok := secretEnv[k]; ok {
-				if encryptedSecrets != nil {
-					encrypted := encryptedSecrets.(map[string]interface{})
-					// opentoolchain API does not return original secret values,
-					// it returns encrypted strings instead, we save these during resource creation or update
-					// check if encrypted secret did not change, to determine if update is required
-					if encrypted[k] != newVal {
-						envMap[k] = newVal // encrypted value changed, force update
-					}
-				} else {
-					envMap[k] = newVal
-				}
-			} else {
-				// key no longer exists, delete to force update
-				delete(envMap, k)
-			}
-		}
-		d.Set("secret_env", envMap)
-	}
+	// opentoolchain API does not return original secret values, it returns encrypted strings instead,
+	// we can only detect if property was modified
+	readSecretMapDrift(d, "encrypted_secrets", "secret_hashes", getEnvMap(pipeline.EnvProperties, "SECURE"))
 
 	// log.Printf("[DEBUG] Read tekton pipeline: %v", dbgPrint(pipeline))
 
@@ -197,66 +186,60 @@ func resourceOpenToolchainPipelinePropertiesCreate(ctx context.Context, d *schem
 	envID := d.Get("env_id").(string)
 
 	config := m.(*ProviderConfig)
-	c := config.OTClient
 
 	envIDParts := strings.Split(envID, ":")
 	region := envIDParts[len(envIDParts)-1]
 
-	patchOptions := &oc.PatchTektonPipelineOptions{
-		GUID:   &guid,
-		Region: &region,
-	}
-
-	// we have to read existing envProperties first
-	pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-		GUID:   &guid,
-		Region: &region,
-	})
-
-	if err != nil {
-		return diag.Errorf("Error reading tekton pipeline: %s", err)
-	}
-
-	currentEnv := pipeline.EnvProperties
-
 	textEnv, txtOk := d.GetOk("text_env")
 	secretEnv, secOk := d.GetOk("secret_env")
 	deletedKeys, delOk := d.GetOk("deleted_keys")
 
-	envMap := make(map[string]interface{})
+	// state is only updated after the patch succeeds
+	var newKeys []interface{}
+	var originalProps []interface{}
 
-	for _, p := range currentEnv {
-		envMap[*p.Name] = p
-	}
+	pipeline, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		currentEnv := pipeline.EnvProperties
 
-	matchedKeys, newKeys := matchEnvironmentKeys(envMap, textEnv, secretEnv, deletedKeys)
-	originalProps := createOriginalProps(envMap, matchedKeys)
+		envMap := make(map[string]interface{})
 
-	d.Set("new_keys", newKeys)
-	d.Set("original_properties", originalProps)
+		for _, p := range currentEnv {
+			envMap[*p.Name] = p
+		}
 
-	if txtOk || secOk || delOk {
-		patchOptions.EnvProperties = makeEnvPatch(currentEnv, textEnv, secretEnv, deletedKeys, originalProps)
+		var matchedKeys []interface{}
+		matchedKeys, newKeys = matchEnvironmentKeys(envMap, textEnv, secretEnv, deletedKeys)
+		originalProps = createOriginalProps(envMap, matchedKeys)
+
+		if !(txtOk || secOk || delOk) {
+			return nil, nil
+		}
 
 		// log.Printf("[DEBUG] Patching tekton pipeline: %v", dbgPrint(patchOptions))
 
-		patchedPipeline, _, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+		return &oc.PatchTektonPipelineOptions{
+			EnvProperties: makeEnvPatch(currentEnv, textEnv, secretEnv, deletedKeys, originalProps),
+		}, nil
+	})
 
-		if err != nil {
-			return diag.Errorf("Failed patching tekton pipeline: %s", err)
-		}
+	if err != nil {
+		return diag.Errorf("Failed patching tekton pipeline: %s", err)
+	}
 
-		if patchedPipeline != nil {
-			encryptedSecrets := make(map[string]string)
+	d.Set("new_keys", newKeys)
+	d.Set("original_properties", originalProps)
 
-			for _, v := range patchedPipeline.EnvProperties {
-				if *v.Type == "SECURE" {
-					encryptedSecrets[*v.Name] = *v.Value
-				}
-			}
+	if txtOk || secOk || delOk {
+		encryptedSecrets := make(map[string]string)
 
-			d.Set("encrypted_secrets", encryptedSecrets)
+		for _, v := range pipeline.EnvProperties {
+			if *v.Type == "SECURE" {
+				encryptedSecrets[*v.Name] = *v.Value
+			}
 		}
+
+		d.Set("encrypted_secrets", encryptedSecrets)
+		d.Set("secret_hashes", hashSecretMap(secretEnv.(map[string]interface{})))
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", *pipeline.ID, envID))
@@ -274,28 +257,10 @@ func resourceOpenToolchainPipelinePropertiesDelete(ctx context.Context, d *schem
 	region := envIDParts[len(envIDParts)-1]
 
 	config := m.(*ProviderConfig)
-	c := config.OTClient
-
-	patchOptions := &oc.PatchTektonPipelineOptions{
-		GUID:   &guid,
-		Region: &region,
-	}
 
 	originalProps := d.Get("original_properties")
 
 	if originalProps != nil {
-		// we have to read existing envProperties first
-		pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-			GUID:   &guid,
-			Region: &region,
-		})
-
-		if err != nil {
-			return diag.Errorf("Error reading tekton pipeline: %s", err)
-		}
-
-		currentEnv := pipeline.EnvProperties
-
 		textEnv := d.Get("text_env")
 		secretEnv := d.Get("secret_env")
 		newKeys := d.Get("new_keys")
@@ -333,9 +298,11 @@ func resourceOpenToolchainPipelinePropertiesDelete(ctx context.Context, d *schem
 			deletedKeys = append(deletedKeys, newKeys.([]interface{})...)
 		}
 
-		patchOptions.EnvProperties = makeEnvPatch(currentEnv, nil, nil, deletedKeys, originalProps)
-
-		_, _, err = c.PatchTektonPipelineWithContext(ctx, patchOptions)
+		_, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+			return &oc.PatchTektonPipelineOptions{
+				EnvProperties: makeEnvPatch(pipeline.EnvProperties, nil, nil, deletedKeys, originalProps),
+			}, nil
+		})
 
 		if err != nil {
 			return diag.Errorf("Failed deleting tekton pipeline: %s", err)
@@ -347,42 +314,32 @@ func resourceOpenToolchainPipelinePropertiesDelete(ctx context.Context, d *schem
 }
 
 func resourceOpenToolchainPipelinePropertiesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	if d.HasChange("text_env") || d.HasChange("secret_env") || d.HasChange("deleted_keys") {
+	if d.HasChange("text_env") || secretMapChanged(d, "secret_env", "secret_hashes") || d.HasChange("deleted_keys") {
 		guid := d.Get("guid").(string)
 		envID := d.Get("env_id").(string)
 
 		config := m.(*ProviderConfig)
-		c := config.OTClient
 
 		envIDParts := strings.Split(envID, ":")
 		region := envIDParts[len(envIDParts)-1]
 
-		// we have to read existing envProperties first
-		pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-			GUID:   &guid,
-			Region: &region,
-		})
-
-		if err != nil {
-			return diag.Errorf("Error reading tekton pipeline: %s", err)
-		}
-
-		currentEnv := pipeline.EnvProperties
 		textEnv := d.Get("text_env")
 		secretEnv := d.Get("secret_env")
 		deletedKeys := d.Get("deleted_keys")
 		originalProps := d.Get("original_properties")
 		newKeys := d.Get("new_keys")
 
-		newOriginalProps, updatedNewKeys, _ := updateOriginalProps(currentEnv, textEnv, secretEnv, deletedKeys, newKeys, originalProps)
+		var newOriginalProps []interface{}
+		var updatedNewKeys []interface{}
 
-		patchOptions := &oc.PatchTektonPipelineOptions{
-			GUID:          &guid,
-			Region:        &region,
-			EnvProperties: makeEnvPatch(currentEnv, textEnv, secretEnv, deletedKeys, newOriginalProps),
-		}
+		patchedPipeline, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+			currentEnv := pipeline.EnvProperties
+			newOriginalProps, updatedNewKeys, _ = updateOriginalProps(currentEnv, textEnv, secretEnv, deletedKeys, newKeys, originalProps)
 
-		patchedPipeline, _, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+			return &oc.PatchTektonPipelineOptions{
+				EnvProperties: makeEnvPatch(currentEnv, textEnv, secretEnv, deletedKeys, newOriginalProps),
+			}, nil
+		})
 
 		if err != nil {
 			return diag.Errorf("Failed patching tekton pipeline: %s", err)
@@ -402,6 +359,8 @@ func resourceOpenToolchainPipelinePropertiesUpdate(ctx context.Context, d *schem
 			}
 		}
 
+		d.Set("secret_hashes", hashSecretMap(secretEnv.(map[string]interface{})))
+
 		// remove any values from original_properties that are no longer overridden
 		props := cleanupOriginalProps(textEnv, secretEnv, deletedKeys, newOriginalProps)
 		d.Set("original_properties", props)
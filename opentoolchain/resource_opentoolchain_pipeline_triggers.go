@@ -133,33 +133,23 @@ func resourceOpenToolchainPipelineTriggersCreate(ctx context.Context, d *schema.
 	envID := d.Get("env_id").(string)
 
 	config := m.(*ProviderConfig)
-	c := config.OTClient
 
 	envIDParts := strings.Split(envID, ":")
 	region := envIDParts[len(envIDParts)-1]
 
 	// we have to read existing triggers first
-	pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-		GUID:   &guid,
-		Region: &region,
-	})
-
-	if err != nil {
-		return diag.Errorf("Error reading tekton pipeline: %s", err)
-	}
-
-	if triggers, ok := d.GetOk("trigger"); ok {
-		patchOptions := &oc.PatchTektonPipelineOptions{
-			GUID:     &guid,
-			Region:   &region,
-			Triggers: createTriggerPatch(triggers.(*schema.Set).List(), pipeline.Triggers),
+	pipeline, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		if triggers, ok := d.GetOk("trigger"); ok {
+			return &oc.PatchTektonPipelineOptions{
+				Triggers: createTriggerPatch(triggers.(*schema.Set).List(), pipeline.Triggers),
+			}, nil
 		}
 
-		_, _, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+		return nil, nil
+	})
 
-		if err != nil {
-			return diag.Errorf("Failed patching tekton pipeline: %s", err)
-		}
+	if err != nil {
+		return diag.Errorf("Failed patching tekton pipeline: %s", err)
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", *pipeline.ID, envID))
@@ -176,27 +166,14 @@ func resourceOpenToolchainPipelineTriggersUpdate(ctx context.Context, d *schema.
 		region := envIDParts[len(envIDParts)-1]
 
 		config := m.(*ProviderConfig)
-		c := config.OTClient
-
-		// we have to read existing envProperties first
-		pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-			GUID:   &guid,
-			Region: &region,
-		})
-
-		if err != nil {
-			return diag.Errorf("Error reading tekton pipeline: %s", err)
-		}
 
 		triggers := d.Get("trigger")
 
-		patchOptions := &oc.PatchTektonPipelineOptions{
-			GUID:     &guid,
-			Region:   &region,
-			Triggers: createTriggerPatch(triggers.(*schema.Set).List(), pipeline.Triggers),
-		}
-
-		_, _, err = c.PatchTektonPipelineWithContext(ctx, patchOptions)
+		_, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+			return &oc.PatchTektonPipelineOptions{
+				Triggers: createTriggerPatch(triggers.(*schema.Set).List(), pipeline.Triggers),
+			}, nil
+		})
 
 		if err != nil {
 			return diag.Errorf("Failed patching tekton pipeline: %s", err)
@@ -229,29 +206,11 @@ func createTriggerPatch(triggers []interface{}, currentPipelineTriggers []oc.Tek
 
 	for _, t := range currentPipelineTriggers {
 		if existing, ok := triggerMap[*t.Name]; ok {
-			t.Disabled = getBoolPtr(!existing["enabled"].(bool))
-			pattern := existing["pattern"].(string)
-			branch := existing["branch"].(string)
-
-			// we should not be setting branch or pattern for non-scm triggers
-			// this should be sufficient check
-			if t.ScmSource != nil {
-				if pattern != "" && branch != "" {
-					log.Printf("[WARN] Both trigger (%s) branch and pattern were set, branch setting will be used: %s", *t.Name, branch)
-				}
-
-				if pattern != "" {
-					t.ScmSource.Pattern = getStringPtr(pattern)
-					t.ScmSource.Branch = nil
-				}
+			// deprecated trigger block has no branch or pattern settings
+			pattern, _ := existing["pattern"].(string)
+			branch, _ := existing["branch"].(string)
 
-				if branch != "" {
-					t.ScmSource.Branch = getStringPtr(branch)
-					t.ScmSource.Pattern = nil
-				}
-			} else {
-				log.Printf("[WARN] Trying to set branch/pattern for non scm trigger: %s", *t.Name)
-			}
+			applyTriggerOverride(&t, existing["enabled"].(bool), branch, pattern)
 		}
 
 		result = append(result, t)
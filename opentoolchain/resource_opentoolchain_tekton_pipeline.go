@@ -6,8 +6,10 @@ import (
 	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
 	"strings"
 )
 
@@ -16,6 +18,20 @@ const (
 	pipelineType        = "tekton"
 )
 
+const (
+	// IBM managed workers
+	tektonPublicWorkerID   = "public"
+	tektonPublicWorkerName = "IBM Managed workers (Tekton Pipelines v0.20.1)"
+)
+
+const (
+	tektonScmTypeGithub = "GitHub"
+	tektonScmTypeGitlab = "GitLab"
+)
+
+// repository types that can be used in tekton definitions and triggers
+var tektonScmTypes = []string{tektonScmTypeGithub, tektonScmTypeGitlab}
+
 func resourceOpenToolchainTektonPipeline() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manage tekton pipeline, do not use this in conjunction with `opentoolchain_tekton_pipeline_overrides` or you may get inconsistent results (WARN: using undocumented APIs)",
@@ -26,6 +42,10 @@ func resourceOpenToolchainTektonPipeline() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeSecretMapHashDiff("secret_env", "secret_hashes"),
+			customizeSecretReferencesDiff("secret_env", "secret_references", getSecretReferencesToolchainID),
+		),
 		Schema: map[string]*schema.Schema{
 			"pipeline_id": {
 				Description: "The tekton pipeline `guid`",
@@ -59,21 +79,34 @@ func resourceOpenToolchainTektonPipeline() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"worker_id": {
+				Description: "Worker that runs pipeline, use `public` for IBM managed workers or `worker_id` of private worker integration",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     tektonPublicWorkerID,
+			},
 			"definition": {
 				Type:     schema.TypeSet,
 				Required: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"github_integration_id": {
-							Description: "Github integration ID",
+							Description: "Github or GitLab integration ID",
 							Type:        schema.TypeString,
 							Required:    true,
 						},
 						"github_url": {
-							Description: "Github repository URL",
+							Description: "Github or GitLab repository URL",
 							Type:        schema.TypeString,
 							Required:    true,
 						},
+						"scm_type": {
+							Description:  "Repository integration type, set to `GitLab` when `github_integration_id` references GitLab integration",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      tektonScmTypeGithub,
+							ValidateFunc: validation.StringInSlice(tektonScmTypes, false),
+						},
 						"branch": {
 							Description: "Github branch that contains tekton definition",
 							Type:        schema.TypeString,
@@ -105,15 +138,22 @@ func resourceOpenToolchainTektonPipeline() *schema.Resource {
 							Default:     true,
 						},
 						"github_integration_id": {
-							Description: "Github integration ID",
+							Description: "Github or GitLab integration ID",
 							Type:        schema.TypeString,
 							Optional:    true,
 						},
 						"github_url": {
-							Description: "Github repository URL",
+							Description: "Github or GitLab repository URL",
 							Type:        schema.TypeString,
 							Optional:    true,
 						},
+						"scm_type": {
+							Description:  "Repository integration type, set to `GitLab` when `github_integration_id` references GitLab integration",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      tektonScmTypeGithub,
+							ValidateFunc: validation.StringInSlice(tektonScmTypes, false),
+						},
 						"name": {
 							Description: "Trigger name",
 							Type:        schema.TypeString,
@@ -175,12 +215,23 @@ func resourceOpenToolchainTektonPipeline() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Optional:  true,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateSecretReferenceMap,
+			},
+			"secret_references": secretReferencesSchema(),
+			"secret_hashes": {
+				Type:        schema.TypeMap,
+				Description: "Salted hashes of `secret_env` values, used internally to detect changes",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 				Sensitive: true,
+				Computed:  true,
 			},
 			"encrypted_secrets": {
 				Type:        schema.TypeMap,
-				Description: "Opentoolchain API does not return actual secret values, this is used internally to track changes to encrypted strings",
+				Description: "Opentoolchain API does not return actual secret values, encrypted strings are used internally to detect changes made outside of Terraform",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -204,6 +255,9 @@ func resourceOpenToolchainTektonPipelineCreate(ctx context.Context, d *schema.Re
 	config := m.(*ProviderConfig)
 	c := config.OTClient
 
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
+
 	pipelineUUID := uuid.NewString()
 	// appending uuid temporarily to be able to retrieve pipeline guid once it is created
 	pipelineName := fmt.Sprintf("%s/%s", name, pipelineUUID)
@@ -296,23 +350,15 @@ func resourceOpenToolchainTektonPipelineCreate(ctx context.Context, d *schema.Re
 	textEnv := d.Get("text_env").(map[string]interface{})
 	secretEnv := d.Get("secret_env").(map[string]interface{})
 
-	patchOptions := &oc.PatchTektonPipelineOptions{
-		GUID:                 &instanceID,
-		Region:               &region,
-		EnvProperties:        expandTektonPipelineEnvProps(textEnv, secretEnv),
-		PipelineDefinitionID: definition.Definition.ID,
-		Inputs:               definition.Inputs,
-		Triggers:             expandTektonPipelineTriggers(triggers.List()),
-		Worker: &oc.PatchTektonPipelineParamsWorker{
-			// current pipeline defaults
-			WorkerID:   getStringPtr("public"),
-			WorkerType: getStringPtr("public"),
-			// TODO: should this be resource parameter? can we get a list of options?
-			WorkerName: getStringPtr("IBM Managed workers (Tekton Pipelines v0.20.1)"),
-		},
-	}
-
-	patchedPipeline, _, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+	patchedPipeline, err := patchTektonPipeline(ctx, config, instanceID, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		return &oc.PatchTektonPipelineOptions{
+			EnvProperties:        expandTektonPipelineEnvProps(textEnv, secretEnv),
+			PipelineDefinitionID: definition.Definition.ID,
+			Inputs:               definition.Inputs,
+			Triggers:             expandTektonPipelineTriggers(triggers.List()),
+			Worker:               expandTektonPipelineWorker(d.Get("worker_id").(string)),
+		}, nil
+	})
 
 	if err != nil {
 		// TODO: try deleting pipeline here to cleanup
@@ -329,6 +375,7 @@ func resourceOpenToolchainTektonPipelineCreate(ctx context.Context, d *schema.Re
 	}
 
 	d.Set("encrypted_secrets", encryptedSecrets)
+	d.Set("secret_hashes", hashSecretMap(secretEnv))
 
 	d.SetId(fmt.Sprintf("%s/%s", instanceID, envID))
 
@@ -365,44 +412,14 @@ func resourceOpenToolchainTektonPipelineRead(ctx context.Context, d *schema.Reso
 	}
 
 	textEnv := getEnvMap(pipeline.EnvProperties, "TEXT")
-	secretEnv := getEnvMap(pipeline.EnvProperties, "SECURE")
 
 	if err := d.Set("text_env", textEnv); err != nil {
 		return diag.Errorf("Error setting tekton pipeline text_env")
 	}
 
 	// there is no way to get actual secret values using current apis, it only provides encrypted strings
-	// we can only detect if property is modified
-	if env, ok := d.GetOk("secret_env"); ok {
-		encryptedSecrets := d.Get("encrypted_secrets").(map[string]interface{})
-		currentSecretEnvMap := env.(map[string]interface{})
-
-		for k := range currentSecretEnvMap {
-			if newVal, ok := secretEnv[k]; ok {
-				if encryptedSecrets[k] != newVal {
-					currentSecretEnvMap[k] = newVal // encrypted value changed, using encrypted string to force update
-				}
-			} else {
-				delete(currentSecretEnvMap, k)
-			}
-		}
-
-		if err := d.Set("secret_env", currentSecretEnvMap); err != nil {
-			return diag.Errorf("Error setting pipeline secret_env: %s", err)
-		}
-	}
-
-	encryptedSecrets := make(map[string]string)
-
-	for _, v := range pipeline.EnvProperties {
-		if *v.Type == "SECURE" {
-			encryptedSecrets[*v.Name] = *v.Value
-		}
-	}
-
-	if err := d.Set("encrypted_secrets", encryptedSecrets); err != nil {
-		return diag.Errorf("Error setting pipeline encrypted_secrets: %s", err)
-	}
+	// we can only detect if property was modified
+	readSecretMapDrift(d, "encrypted_secrets", "secret_hashes", getEnvMap(pipeline.EnvProperties, "SECURE"))
 
 	if pipeline.Status != nil {
 		d.Set("status", *pipeline.Status)
@@ -414,6 +431,14 @@ func resourceOpenToolchainTektonPipelineRead(ctx context.Context, d *schema.Reso
 
 	if pipeline.ToolchainID != nil {
 		d.Set("toolchain_id", *pipeline.ToolchainID)
+
+		references, err := resolveSecretReferences(ctx, c, *pipeline.ToolchainID, region, d.Get("secret_env").(map[string]interface{}))
+
+		if err != nil {
+			log.Printf("[WARN] Unable to resolve tekton pipeline secret references: %s", err)
+		} else if err := d.Set("secret_references", references); err != nil {
+			return diag.Errorf("Error setting pipeline secret_references: %s", err)
+		}
 	}
 
 	if pipeline.Name != nil {
@@ -486,10 +511,7 @@ func resourceOpenToolchainTektonPipelineUpdate(ctx context.Context, d *schema.Re
 		}
 	}
 
-	patchOptions := &oc.PatchTektonPipelineOptions{
-		GUID:   &pipelineID,
-		Region: &region,
-	}
+	patchOptions := &oc.PatchTektonPipelineOptions{}
 
 	if d.HasChange("definition") {
 		inputs := d.Get("definition").(*schema.Set)
@@ -517,15 +539,23 @@ func resourceOpenToolchainTektonPipelineUpdate(ctx context.Context, d *schema.Re
 		patchOptions.Triggers = expandTektonPipelineTriggers(triggers.List())
 	}
 
-	if d.HasChange("text_env") || d.HasChange("secret_env") {
+	secretEnvChanged := secretMapChanged(d, "secret_env", "secret_hashes")
+
+	if d.HasChange("text_env") || secretEnvChanged {
 		textEnv := d.Get("text_env").(map[string]interface{})
 		secretEnv := d.Get("secret_env").(map[string]interface{})
 		patchOptions.EnvProperties = expandTektonPipelineEnvProps(textEnv, secretEnv)
 	}
 
+	if d.HasChange("worker_id") {
+		patchOptions.Worker = expandTektonPipelineWorker(d.Get("worker_id").(string))
+	}
+
 	// add other conditions here
-	if d.HasChange("definition") || d.HasChange("trigger") || d.HasChange("text_env") || d.HasChange("secret_env") {
-		patchedPipeline, _, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+	if d.HasChange("definition") || d.HasChange("trigger") || d.HasChange("text_env") || d.HasChange("worker_id") || secretEnvChanged {
+		patchedPipeline, err := patchTektonPipeline(ctx, config, pipelineID, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+			return patchOptions, nil
+		})
 
 		if err != nil {
 			return diag.Errorf("Failed updating tekton pipeline: %s", err)
@@ -540,6 +570,10 @@ func resourceOpenToolchainTektonPipelineUpdate(ctx context.Context, d *schema.Re
 		}
 
 		d.Set("encrypted_secrets", encryptedSecrets)
+
+		if patchOptions.EnvProperties != nil {
+			d.Set("secret_hashes", hashSecretMap(d.Get("secret_env").(map[string]interface{})))
+		}
 	}
 
 	return resourceOpenToolchainTektonPipelineRead(ctx, d, m)
@@ -554,6 +588,7 @@ func expandTektonPipelineDefinitionInputs(c *oc.OpenToolchainV1, envID *string,
 		branch := input["branch"].(string)
 		path := input["path"].(string)
 		url := input["github_url"].(string)
+		scmType := input["scm_type"].(string)
 
 		result[index] = oc.CreateTektonPipelineDefinitionParamsInputsItem{
 			Type:              getStringPtr("scm"),
@@ -561,7 +596,7 @@ func expandTektonPipelineDefinitionInputs(c *oc.OpenToolchainV1, envID *string,
 			ScmSource: &oc.CreateTektonPipelineDefinitionParamsInputsItemScmSource{
 				Path:            &path,
 				URL:             &url,
-				Type:            getStringPtr("GitHub"),
+				Type:            &scmType,
 				BlindConnection: getBoolPtr(false),
 				Branch:          &branch,
 			},
@@ -571,6 +606,22 @@ func expandTektonPipelineDefinitionInputs(c *oc.OpenToolchainV1, envID *string,
 	return result
 }
 
+func expandTektonPipelineWorker(workerID string) *oc.PatchTektonPipelineParamsWorker {
+	if workerID == "" || workerID == tektonPublicWorkerID {
+		return &oc.PatchTektonPipelineParamsWorker{
+			WorkerID:   getStringPtr(tektonPublicWorkerID),
+			WorkerType: getStringPtr("public"),
+			// TODO: can we get a list of options?
+			WorkerName: getStringPtr(tektonPublicWorkerName),
+		}
+	}
+
+	return &oc.PatchTektonPipelineParamsWorker{
+		WorkerID:   &workerID,
+		WorkerType: getStringPtr("private"),
+	}
+}
+
 func expandTektonPipelineEnvProps(text map[string]interface{}, secret map[string]interface{}) []oc.EnvProperty {
 	var result []oc.EnvProperty
 
@@ -605,43 +656,55 @@ func expandTektonPipelineTriggers(t []interface{}) []oc.TektonPipelineTrigger {
 	result := make([]oc.TektonPipelineTrigger, len(t))
 
 	for index, trig := range t {
-		trigger := trig.(map[string]interface{})
-		name := trigger["name"].(string)
-		eventListener := trigger["event_listener"].(string)
-		triggerType := trigger["type"].(string)
-		enabled := trigger["enabled"].(bool)
-
-		result[index] = oc.TektonPipelineTrigger{
-			ID:            getStringPtr(uuid.NewString()),
-			Name:          &name,
-			EventListener: &eventListener,
-			Type:          &triggerType,
-			Disabled:      getBoolPtr(!enabled),
+		result[index] = expandTektonPipelineTrigger(trig.(map[string]interface{}))
+		result[index].ID = getStringPtr(uuid.NewString())
+	}
+
+	return result
+}
+
+func expandTektonPipelineTrigger(trigger map[string]interface{}) oc.TektonPipelineTrigger {
+	name := trigger["name"].(string)
+	eventListener := trigger["event_listener"].(string)
+	triggerType := trigger["type"].(string)
+	enabled := trigger["enabled"].(bool)
+
+	result := oc.TektonPipelineTrigger{
+		Name:          &name,
+		EventListener: &eventListener,
+		Type:          &triggerType,
+		Disabled:      getBoolPtr(!enabled),
+	}
+
+	if triggerType == "scm" {
+		githubIntegrationID := trigger["github_integration_id"].(string)
+		onPush := trigger["on_push"].(bool)
+		onPR := trigger["on_pull_request"].(bool)
+		onPRClosed := trigger["on_pull_request_closed"].(bool)
+		branch := trigger["branch"].(string)
+		pattern := trigger["pattern"].(string)
+		url := trigger["github_url"].(string)
+
+		// overrides resource does not require scm_type for new triggers
+		scmType, _ := trigger["scm_type"].(string)
+
+		if scmType == "" {
+			scmType = tektonScmTypeGithub
 		}
 
-		if triggerType == "scm" {
-			githubIntegrationID := trigger["github_integration_id"].(string)
-			onPush := trigger["on_push"].(bool)
-			onPR := trigger["on_pull_request"].(bool)
-			onPRClosed := trigger["on_pull_request_closed"].(bool)
-			branch := trigger["branch"].(string)
-			pattern := trigger["pattern"].(string)
-			url := trigger["github_url"].(string)
-
-			result[index].ServiceInstanceID = &githubIntegrationID
-
-			result[index].ScmSource = &oc.TektonPipelineTriggerScmSource{
-				URL:     &url,
-				Type:    getStringPtr("GitHub"), // TODO: what other types available?
-				Branch:  &branch,
-				Pattern: &pattern,
-			}
+		result.ServiceInstanceID = &githubIntegrationID
 
-			result[index].Events = &oc.TektonPipelineTriggerEvents{
-				Push:              &onPush,
-				PullRequest:       &onPR,
-				PullRequestClosed: &onPRClosed,
-			}
+		result.ScmSource = &oc.TektonPipelineTriggerScmSource{
+			URL:     &url,
+			Type:    &scmType,
+			Branch:  &branch,
+			Pattern: &pattern,
+		}
+
+		result.Events = &oc.TektonPipelineTriggerEvents{
+			Push:              &onPush,
+			PullRequest:       &onPR,
+			PullRequestClosed: &onPRClosed,
 		}
 	}
 
@@ -658,6 +721,7 @@ func flattenTektonPipelineDefinition(d []oc.TektonPipelineInput) []interface{} {
 				"branch":                *in.ScmSource.Branch,
 				"path":                  *in.ScmSource.Path,
 				"github_url":            *in.ScmSource.URL,
+				"scm_type":              getScmType(in.ScmSource.Type),
 			}
 
 			result = append(result, input)
@@ -671,26 +735,41 @@ func flattenTektonPipelineTriggers(t []oc.TektonPipelineTrigger) []interface{} {
 	var result []interface{}
 
 	for _, trg := range t {
-		trigger := map[string]interface{}{
-			"id":             *trg.ID,
-			"enabled":        !*trg.Disabled,
-			"name":           *trg.Name,
-			"event_listener": *trg.EventListener,
-			"type":           *trg.Type,
-		}
-
-		if *trg.Type == "scm" {
-			trigger["github_integration_id"] = *trg.ServiceInstanceID
-			trigger["github_url"] = *trg.ScmSource.URL
-			trigger["on_pull_request"] = *trg.Events.PullRequest
-			trigger["on_pull_request_closed"] = *trg.Events.PullRequestClosed
-			trigger["on_push"] = *trg.Events.Push
-			trigger["branch"] = *trg.ScmSource.Branch
-			trigger["pattern"] = *trg.ScmSource.Pattern
-		}
-
+		trigger := flattenTektonPipelineTrigger(trg)
+		trigger["id"] = *trg.ID
 		result = append(result, trigger)
 	}
 
 	return result
 }
+
+func flattenTektonPipelineTrigger(trg oc.TektonPipelineTrigger) map[string]interface{} {
+	trigger := map[string]interface{}{
+		"enabled":        !*trg.Disabled,
+		"name":           *trg.Name,
+		"event_listener": *trg.EventListener,
+		"type":           *trg.Type,
+	}
+
+	if *trg.Type == "scm" {
+		trigger["github_integration_id"] = *trg.ServiceInstanceID
+		trigger["github_url"] = *trg.ScmSource.URL
+		trigger["scm_type"] = getScmType(trg.ScmSource.Type)
+		trigger["on_pull_request"] = *trg.Events.PullRequest
+		trigger["on_pull_request_closed"] = *trg.Events.PullRequestClosed
+		trigger["on_push"] = *trg.Events.Push
+		trigger["branch"] = *trg.ScmSource.Branch
+		trigger["pattern"] = *trg.ScmSource.Pattern
+	}
+
+	return trigger
+}
+
+// API omits scm source type for some older pipelines, those are always GitHub
+func getScmType(scmType *string) string {
+	if scmType == nil || *scmType == "" {
+		return tektonScmTypeGithub
+	}
+
+	return *scmType
+}
@@ -4,20 +4,28 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceOpenToolchainTektonPipelineOverrides() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Update *existing* tekton pipeline properties and triggers. If property exists, it will be updated in place, otherwise new one will be added. If trigger exists - it will be updated in place, otherwise it will be IGNORED (adding new triggers is not supported). When this resource is destroyed, original pipeline properties are restored. (WARN: using unpublished APIs)",
+		Description:   "Update *existing* tekton pipeline properties and triggers. If property exists, it will be updated in place, otherwise new one will be added. If trigger exists - it will be updated in place, otherwise new one will be added (`type` and `event_listener` are required for new triggers). When this resource is destroyed, original pipeline properties and triggers are restored, new properties and triggers are removed. (WARN: using unpublished APIs)",
 		CreateContext: resourceOpenToolchainTektonPipelineOverridesCreate,
 		ReadContext:   resourceOpenToolchainTektonPipelineOverridesRead,
 		DeleteContext: resourceOpenToolchainTektonPipelineOverridesDelete,
 		UpdateContext: resourceOpenToolchainTektonPipelineOverridesUpdate,
+		CustomizeDiff: customdiff.All(
+			customizeSecretMapHashDiff("secret_env", "secret_hashes"),
+			customizeSecretReferencesDiff("secret_env", "secret_references", getOverridesSecretReferencesToolchainID),
+		),
 		Schema: map[string]*schema.Schema{
 			"guid": {
 				Description: "The tekton pipeline `guid`",
@@ -60,9 +68,11 @@ func resourceOpenToolchainTektonPipelineOverrides() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Optional: true,
+				Optional:     true,
+				ValidateFunc: validateSecretReferenceMap,
 				//Sensitive: true,
 			},
+			"secret_references": secretReferencesSchema(),
 			"trigger": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -74,13 +84,53 @@ func resourceOpenToolchainTektonPipelineOverrides() *schema.Resource {
 							Computed:    true,
 						},
 						"github_integration_guid": {
-							Description: "Github integration ID",
+							Description: "Github integration ID, only used when adding new trigger",
 							Type:        schema.TypeString,
+							Optional:    true,
 							Computed:    true,
 						},
+						"github_url": {
+							Description: "Github repository URL, only used when adding new trigger",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"scm_type": {
+							Description:  "Repository integration type, set to `GitLab` when `github_integration_guid` references GitLab integration, only used when adding new trigger",
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice(tektonScmTypes, false),
+							Optional:     true,
+							Computed:     true,
+						},
 						"type": {
-							Description: "Trigger type",
+							Description:  "Trigger type, required when adding new trigger",
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice([]string{"scm", "manual"}, false),
+							Optional:     true,
+							Computed:     true,
+						},
+						"event_listener": {
+							Description: "Event Listener name (from .tekton pipeline definition), required when adding new trigger",
 							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+						"on_pull_request": {
+							Description: "Trigger when pull request is opened or updated, only used when adding new trigger",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"on_pull_request_closed": {
+							Description: "Trigger when pull request is closed, only used when adding new trigger",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Computed:    true,
+						},
+						"on_push": {
+							Description: "Trigger when commit is pushed, only used when adding new trigger",
+							Type:        schema.TypeBool,
+							Optional:    true,
 							Computed:    true,
 						},
 						"enabled": {
@@ -146,9 +196,55 @@ func resourceOpenToolchainTektonPipelineOverrides() *schema.Resource {
 				Sensitive: true,
 				Computed:  true,
 			},
+			"new_triggers": {
+				Description: "IDs of triggers that were not part of original list (used internally)",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+			"original_triggers": {
+				Type:        schema.TypeList,
+				Description: "Used internally to restore pipeline triggers to their original state once resource is deleted",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"branch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pattern": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Computed: true,
+			},
+			"secret_hashes": {
+				Type:        schema.TypeMap,
+				Description: "Salted hashes of `secret_env` values, used internally to detect changes",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Sensitive: true,
+				Computed:  true,
+			},
 			"encrypted_secrets": {
 				Type:        schema.TypeMap,
-				Description: "Opentoolchain API does not return actual secret values, this is used internally to track changes to encrypted strings",
+				Description: "Opentoolchain API does not return actual secret values, encrypted strings are used internally to detect changes made outside of Terraform",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -182,7 +278,6 @@ func resourceOpenToolchainTektonPipelineOverridesRead(ctx context.Context, d *sc
 	}
 
 	textEnv := getEnvMap(pipeline.EnvProperties, "TEXT")
-	secretEnv := getEnvMap(pipeline.EnvProperties, "SECURE")
 
 	if env, ok := d.GetOk("text_env"); ok {
 		envMap := env.(map[string]interface{})
@@ -197,29 +292,9 @@ func resourceOpenToolchainTektonPipelineOverridesRead(ctx context.Context, d *sc
 		d.Set("text_env", envMap)
 	}
 
-	if env, ok := d.GetOk("secret_env"); ok {
-		encryptedSecrets := d.Get("encrypted_secrets")
-		envMap := env.(map[string]interface{})
-		for k := range envMap {
-			if newVal, ok := secretEnv[k]; ok {
-				if encryptedSecrets != nil {
-					encrypted := encryptedSecrets.(map[string]interface{})
-					// opentoolchain API does not return original secret values,
-					// it returns encrypted strings instead, we save these during resource creation or update
-					// check if encrypted secret did not change, to determine if update is required
-					if encrypted[k] != newVal {
-						envMap[k] = newVal // encrypted value changed, force update
-					}
-				} else {
-					envMap[k] = newVal
-				}
-			} else {
-				// key no longer exists, delete to force update
-				delete(envMap, k)
-			}
-		}
-		d.Set("secret_env", envMap)
-	}
+	// opentoolchain API does not return original secret values, it returns encrypted strings instead,
+	// we can only detect if property was modified
+	readSecretMapDrift(d, "encrypted_secrets", "secret_hashes", getEnvMap(pipeline.EnvProperties, "SECURE"))
 
 	if triggers, ok := d.GetOk("trigger"); ok {
 		pipelineTriggerMap := make(map[string]oc.TektonPipelineTrigger)
@@ -236,26 +311,54 @@ func resourceOpenToolchainTektonPipelineOverridesRead(ctx context.Context, d *sc
 			tMap := t.(map[string]interface{})
 			triggerName := tMap["name"].(string)
 
-			if pipelineTrigger, ok := pipelineTriggerMap[triggerName]; ok {
-				tMap["id"] = *pipelineTrigger.ID
-				tMap["type"] = *pipelineTrigger.Type
-				tMap["enabled"] = !*pipelineTrigger.Disabled
+			pipelineTrigger, ok := pipelineTriggerMap[triggerName]
+
+			if !ok {
+				// dropping trigger from state will force it to be added again
+				log.Printf("[WARN] Trigger '%s' does not exist, it will be added", triggerName)
+				continue
+			}
 
-				if pipelineTrigger.ServiceInstanceID != nil {
-					tMap["github_integration_guid"] = *pipelineTrigger.ServiceInstanceID
+			tMap["id"] = *pipelineTrigger.ID
+			tMap["type"] = *pipelineTrigger.Type
+			tMap["enabled"] = !*pipelineTrigger.Disabled
+
+			if pipelineTrigger.EventListener != nil {
+				tMap["event_listener"] = *pipelineTrigger.EventListener
+			}
+
+			if pipelineTrigger.ServiceInstanceID != nil {
+				tMap["github_integration_guid"] = *pipelineTrigger.ServiceInstanceID
+			}
+
+			if pipelineTrigger.ScmSource != nil {
+				if pipelineTrigger.ScmSource.URL != nil {
+					tMap["github_url"] = *pipelineTrigger.ScmSource.URL
 				}
 
-				if pipelineTrigger.ScmSource != nil {
-					if pipelineTrigger.ScmSource.Branch != nil {
-						tMap["branch"] = *pipelineTrigger.ScmSource.Branch
-					}
+				tMap["scm_type"] = getScmType(pipelineTrigger.ScmSource.Type)
 
-					if pipelineTrigger.ScmSource.Pattern != nil {
-						tMap["pattern"] = *pipelineTrigger.ScmSource.Pattern
-					}
+				if pipelineTrigger.ScmSource.Branch != nil {
+					tMap["branch"] = *pipelineTrigger.ScmSource.Branch
+				}
+
+				if pipelineTrigger.ScmSource.Pattern != nil {
+					tMap["pattern"] = *pipelineTrigger.ScmSource.Pattern
+				}
+			}
+
+			if pipelineTrigger.Events != nil {
+				if pipelineTrigger.Events.Push != nil {
+					tMap["on_push"] = *pipelineTrigger.Events.Push
+				}
+
+				if pipelineTrigger.Events.PullRequest != nil {
+					tMap["on_pull_request"] = *pipelineTrigger.Events.PullRequest
+				}
+
+				if pipelineTrigger.Events.PullRequestClosed != nil {
+					tMap["on_pull_request_closed"] = *pipelineTrigger.Events.PullRequestClosed
 				}
-			} else {
-				log.Printf("[WARN] Trigger '%s' does not exist, it will be ignored", triggerName)
 			}
 
 			result = append(result, tMap)
@@ -270,6 +373,14 @@ func resourceOpenToolchainTektonPipelineOverridesRead(ctx context.Context, d *sc
 	d.Set("toolchain_guid", *pipeline.ToolchainID)
 	d.Set("toolchain_crn", *pipeline.ToolchainCRN)
 
+	references, err := resolveSecretReferences(ctx, c, *pipeline.ToolchainID, region, d.Get("secret_env").(map[string]interface{}))
+
+	if err != nil {
+		log.Printf("[WARN] Unable to resolve tekton pipeline secret references: %s", err)
+	} else if err := d.Set("secret_references", references); err != nil {
+		return diag.Errorf("Error setting pipeline secret_references: %s", err)
+	}
+
 	return nil
 }
 
@@ -278,71 +389,82 @@ func resourceOpenToolchainTektonPipelineOverridesCreate(ctx context.Context, d *
 	envID := d.Get("env_id").(string)
 
 	config := m.(*ProviderConfig)
-	c := config.OTClient
 
 	envIDParts := strings.Split(envID, ":")
 	region := envIDParts[len(envIDParts)-1]
 
-	patchOptions := &oc.PatchTektonPipelineOptions{
-		GUID:   &guid,
-		Region: &region,
-	}
-
-	// we have to read existing envProperties first
-	pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-		GUID:   &guid,
-		Region: &region,
-	})
-
-	if err != nil {
-		return diag.Errorf("Error reading tekton pipeline: %s", err)
-	}
-
-	currentEnv := pipeline.EnvProperties
-
 	textEnv, txtOk := d.GetOk("text_env")
 	secretEnv, secOk := d.GetOk("secret_env")
 	deletedKeys, delOk := d.GetOk("deleted_keys")
 	triggers, trigOk := d.GetOk("trigger")
 
-	envMap := make(map[string]interface{})
+	// makePatch may run more than once, so state is only updated after the patch succeeds
+	var newKeys []interface{}
+	var originalProps []interface{}
+	var originalTriggers []interface{}
+	var newTriggers []interface{}
 
-	for _, p := range currentEnv {
-		envMap[*p.Name] = p
-	}
+	pipeline, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		currentEnv := pipeline.EnvProperties
 
-	matchedKeys, newKeys := matchEnvironmentKeys(envMap, textEnv, secretEnv, deletedKeys)
-	originalProps := createOriginalProps(envMap, matchedKeys)
+		envMap := make(map[string]interface{})
 
-	d.Set("new_keys", newKeys)
-	d.Set("original_properties", originalProps)
+		for _, p := range currentEnv {
+			envMap[*p.Name] = p
+		}
 
-	if txtOk || secOk || delOk || trigOk {
-		patchOptions.EnvProperties = makeEnvPatch(currentEnv, textEnv, secretEnv, deletedKeys, originalProps)
+		var matchedKeys []interface{}
+		matchedKeys, newKeys = matchEnvironmentKeys(envMap, textEnv, secretEnv, deletedKeys)
+		originalProps = createOriginalProps(envMap, matchedKeys)
+		originalTriggers, newTriggers = nil, nil
+
+		if !(txtOk || secOk || delOk || trigOk) {
+			return nil, nil
+		}
+
+		patchOptions := &oc.PatchTektonPipelineOptions{
+			EnvProperties: makeEnvPatch(currentEnv, textEnv, secretEnv, deletedKeys, originalProps),
+		}
 
 		if triggers != nil {
-			patchOptions.Triggers = createTriggerPatch(triggers.(*schema.Set).List(), pipeline.Triggers)
+			patchTriggers, patchOriginalTriggers, patchNewTriggers, err := makeTriggerOverridesPatch(triggers.(*schema.Set).List(), pipeline.Triggers, nil, nil)
+
+			if err != nil {
+				return nil, err
+			}
+
+			patchOptions.Triggers = patchTriggers
+			originalTriggers, newTriggers = patchOriginalTriggers, patchNewTriggers
 		}
 
 		// log.Printf("[DEBUG] Patching tekton pipeline: %v", dbgPrint(patchOptions))
 
-		patchedPipeline, patchResp, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+		return patchOptions, nil
+	})
 
-		if err != nil {
-			return diag.Errorf("Failed patching tekton pipeline: %s\n%s", err, patchResp)
-		}
+	if err != nil {
+		return diag.Errorf("Failed patching tekton pipeline: %s", err)
+	}
 
-		if patchedPipeline != nil {
-			encryptedSecrets := make(map[string]string)
+	d.Set("new_keys", newKeys)
+	d.Set("original_properties", originalProps)
 
-			for _, v := range patchedPipeline.EnvProperties {
-				if *v.Type == "SECURE" {
-					encryptedSecrets[*v.Name] = *v.Value
-				}
-			}
+	if triggers != nil {
+		d.Set("original_triggers", originalTriggers)
+		d.Set("new_triggers", newTriggers)
+	}
 
-			d.Set("encrypted_secrets", encryptedSecrets)
+	if txtOk || secOk || delOk || trigOk {
+		encryptedSecrets := make(map[string]string)
+
+		for _, v := range pipeline.EnvProperties {
+			if *v.Type == "SECURE" {
+				encryptedSecrets[*v.Name] = *v.Value
+			}
 		}
+
+		d.Set("encrypted_secrets", encryptedSecrets)
+		d.Set("secret_hashes", hashSecretMap(secretEnv.(map[string]interface{})))
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", *pipeline.ID, envID))
@@ -357,31 +479,13 @@ func resourceOpenToolchainTektonPipelineOverridesDelete(ctx context.Context, d *
 	envID := d.Get("env_id").(string)
 
 	config := m.(*ProviderConfig)
-	c := config.OTClient
 
 	envIDParts := strings.Split(envID, ":")
 	region := envIDParts[len(envIDParts)-1]
 
-	patchOptions := &oc.PatchTektonPipelineOptions{
-		GUID:   &guid,
-		Region: &region,
-	}
-
 	originalProps := d.Get("original_properties")
 
 	if originalProps != nil {
-		// we have to read existing envProperties first
-		pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-			GUID:   &guid,
-			Region: &region,
-		})
-
-		if err != nil {
-			return diag.Errorf("Error reading tekton pipeline: %s", err)
-		}
-
-		currentEnv := pipeline.EnvProperties
-
 		textEnv := d.Get("text_env")
 		secretEnv := d.Get("secret_env")
 		newKeys := d.Get("new_keys")
@@ -419,9 +523,22 @@ func resourceOpenToolchainTektonPipelineOverridesDelete(ctx context.Context, d *
 			deletedKeys = append(deletedKeys, newKeys.([]interface{})...)
 		}
 
-		patchOptions.EnvProperties = makeEnvPatch(currentEnv, nil, nil, deletedKeys, originalProps)
+		originalTriggers := d.Get("original_triggers")
+		newTriggers := d.Get("new_triggers")
+
+		_, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+			// restores original triggers and removes the ones that were added
+			triggers, _, _, err := makeTriggerOverridesPatch(nil, pipeline.Triggers, originalTriggers, newTriggers)
+
+			if err != nil {
+				return nil, err
+			}
 
-		_, _, err = c.PatchTektonPipelineWithContext(ctx, patchOptions)
+			return &oc.PatchTektonPipelineOptions{
+				EnvProperties: makeEnvPatch(pipeline.EnvProperties, nil, nil, deletedKeys, originalProps),
+				Triggers:      triggers,
+			}, nil
+		})
 
 		if err != nil {
 			return diag.Errorf("Failed deleting tekton pipeline: %s", err)
@@ -433,61 +550,75 @@ func resourceOpenToolchainTektonPipelineOverridesDelete(ctx context.Context, d *
 }
 
 func resourceOpenToolchainTektonPipelineOverridesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	if d.HasChange("text_env") || d.HasChange("secret_env") || d.HasChange("deleted_keys") || d.HasChange("trigger") {
+	if d.HasChange("text_env") || secretMapChanged(d, "secret_env", "secret_hashes") || d.HasChange("deleted_keys") || d.HasChange("trigger") {
 		guid := d.Get("guid").(string)
 		envID := d.Get("env_id").(string)
 
 		config := m.(*ProviderConfig)
-		c := config.OTClient
 
 		envIDParts := strings.Split(envID, ":")
 		region := envIDParts[len(envIDParts)-1]
 
-		// we have to read existing envProperties first
-		pipeline, _, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
-			GUID:   &guid,
-			Region: &region,
-		})
-
-		if err != nil {
-			return diag.Errorf("Error reading tekton pipeline: %s", err)
-		}
-
-		currentEnv := pipeline.EnvProperties
 		textEnv := d.Get("text_env")
 		secretEnv := d.Get("secret_env")
 		deletedKeys := d.Get("deleted_keys")
 		originalProps := d.Get("original_properties")
 		newKeys := d.Get("new_keys")
 		triggers := d.Get("trigger")
+		currentOriginalTriggers := d.Get("original_triggers")
+		currentNewTriggers := d.Get("new_triggers")
+
+		// makePatch may run more than once, so it only works with local copies and state is updated after the patch succeeds
+		var newOriginalProps []interface{}
+		var updatedNewKeys []interface{}
+		var patchDeletedKeys interface{}
+		var originalTriggers []interface{}
+		var newTriggers []interface{}
+
+		patchedPipeline, err := patchTektonPipeline(ctx, config, guid, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+			currentEnv := pipeline.EnvProperties
+
+			var deletedNewKeys []interface{}
+			newOriginalProps, updatedNewKeys, deletedNewKeys = updateOriginalProps(currentEnv, textEnv, secretEnv, deletedKeys, newKeys, originalProps)
+			patchDeletedKeys = deletedKeys
+
+			// if new property is deleted, we need to make sure it is removed in patch payload
+			if deletedNewKeys != nil {
+				if deletedKeys != nil {
+					keys := append([]interface{}{}, deletedKeys.([]interface{})...)
+					patchDeletedKeys = append(keys, deletedNewKeys...)
+				} else {
+					patchDeletedKeys = deletedNewKeys
+				}
+			}
 
-		newOriginalProps, updatedNewKeys, deletedNewKeys := updateOriginalProps(currentEnv, textEnv, secretEnv, deletedKeys, newKeys, originalProps)
-
-		// if new property is deleted, we need to make sure it is removed in patch payload
-		if deletedNewKeys != nil {
-			if deletedKeys != nil {
-				deletedKeys = append(deletedKeys.([]interface{}), deletedNewKeys...)
-			} else {
-				deletedKeys = deletedNewKeys
+			patchOptions := &oc.PatchTektonPipelineOptions{
+				EnvProperties: makeEnvPatch(currentEnv, textEnv, secretEnv, patchDeletedKeys, newOriginalProps),
 			}
-		}
 
-		patchOptions := &oc.PatchTektonPipelineOptions{
-			GUID:          &guid,
-			Region:        &region,
-			EnvProperties: makeEnvPatch(currentEnv, textEnv, secretEnv, deletedKeys, newOriginalProps),
-		}
+			if triggers != nil {
+				patchTriggers, patchOriginalTriggers, patchNewTriggers, err := makeTriggerOverridesPatch(triggers.(*schema.Set).List(), pipeline.Triggers, currentOriginalTriggers, currentNewTriggers)
 
-		if triggers != nil {
-			patchOptions.Triggers = createTriggerPatch(triggers.(*schema.Set).List(), pipeline.Triggers)
-		}
+				if err != nil {
+					return nil, err
+				}
 
-		patchedPipeline, _, err := c.PatchTektonPipelineWithContext(ctx, patchOptions)
+				patchOptions.Triggers = patchTriggers
+				originalTriggers, newTriggers = patchOriginalTriggers, patchNewTriggers
+			}
+
+			return patchOptions, nil
+		})
 
 		if err != nil {
 			return diag.Errorf("Failed patching tekton pipeline: %s", err)
 		}
 
+		if triggers != nil {
+			d.Set("original_triggers", originalTriggers)
+			d.Set("new_triggers", newTriggers)
+		}
+
 		if patchedPipeline != nil {
 			encryptedSecrets := make(map[string]string)
 
@@ -500,11 +631,217 @@ func resourceOpenToolchainTektonPipelineOverridesUpdate(ctx context.Context, d *
 			d.Set("encrypted_secrets", encryptedSecrets)
 		}
 
+		d.Set("secret_hashes", hashSecretMap(secretEnv.(map[string]interface{})))
+
 		// remove any values from original_properties that are no longer overridden
-		props := cleanupOriginalProps(textEnv, secretEnv, deletedKeys, newOriginalProps)
+		props := cleanupOriginalProps(textEnv, secretEnv, patchDeletedKeys, newOriginalProps)
 		d.Set("original_properties", props)
 		d.Set("new_keys", updatedNewKeys)
 	}
 
 	return resourceOpenToolchainTektonPipelineOverridesRead(ctx, d, m)
 }
+
+// makeTriggerOverridesPatch applies configured triggers on top of current pipeline triggers. Existing triggers
+// (matched by name) are updated in place and their original settings saved, missing triggers are added.
+// Triggers that are no longer configured are restored from original settings, or removed if they were added
+// by this resource. Returns patched trigger list, updated original_triggers and new_triggers.
+func makeTriggerOverridesPatch(triggers []interface{}, currentPipelineTriggers []oc.TektonPipelineTrigger, originalTriggers interface{}, newTriggers interface{}) ([]oc.TektonPipelineTrigger, []interface{}, []interface{}, error) {
+	triggerMap := make(map[string]map[string]interface{})
+
+	for _, t := range triggers {
+		tMap := t.(map[string]interface{})
+		triggerMap[tMap["name"].(string)] = tMap
+	}
+
+	originalMap := make(map[string]map[string]interface{})
+
+	if originalTriggers != nil {
+		for _, t := range originalTriggers.([]interface{}) {
+			tMap := t.(map[string]interface{})
+			originalMap[tMap["id"].(string)] = tMap
+		}
+	}
+
+	newTriggerIDs := make(map[string]bool)
+
+	if newTriggers != nil {
+		for _, id := range newTriggers.([]interface{}) {
+			newTriggerIDs[id.(string)] = true
+		}
+	}
+
+	result := make([]oc.TektonPipelineTrigger, 0, len(currentPipelineTriggers)+len(triggerMap))
+	var updatedOriginals []interface{}
+	var updatedNewTriggers []interface{}
+	matched := make(map[string]bool)
+
+	for _, t := range currentPipelineTriggers {
+		id := getStringValue(t.ID)
+		configured, ok := triggerMap[getStringValue(t.Name)]
+
+		if ok && !matched[*t.Name] {
+			matched[*t.Name] = true
+
+			if newTriggerIDs[id] {
+				// trigger was added by this resource, it can be replaced completely
+				trigger := expandTektonPipelineTrigger(expandOverridesTriggerMap(configured))
+				trigger.ID = t.ID
+				result = append(result, trigger)
+				updatedNewTriggers = append(updatedNewTriggers, id)
+				continue
+			}
+
+			original, ok := originalMap[id]
+
+			if !ok {
+				original = map[string]interface{}{
+					"id":      id,
+					"name":    getStringValue(t.Name),
+					"enabled": t.Disabled == nil || !*t.Disabled,
+					"branch":  "",
+					"pattern": "",
+				}
+
+				if t.ScmSource != nil {
+					original["branch"] = getStringValue(t.ScmSource.Branch)
+					original["pattern"] = getStringValue(t.ScmSource.Pattern)
+				}
+			}
+
+			updatedOriginals = append(updatedOriginals, original)
+			applyTriggerOverride(&t, configured["enabled"].(bool), configured["branch"].(string), configured["pattern"].(string))
+			result = append(result, t)
+			continue
+		}
+
+		if newTriggerIDs[id] {
+			// trigger was added by this resource and is no longer configured
+			continue
+		}
+
+		if original, ok := originalMap[id]; ok {
+			restored := t
+			restored.Disabled = getBoolPtr(!original["enabled"].(bool))
+
+			if restored.ScmSource != nil {
+				scm := *restored.ScmSource
+				scm.Branch = nil
+				scm.Pattern = nil
+
+				if branch := original["branch"].(string); branch != "" {
+					scm.Branch = &branch
+				}
+
+				if pattern := original["pattern"].(string); pattern != "" {
+					scm.Pattern = &pattern
+				}
+
+				restored.ScmSource = &scm
+			}
+
+			result = append(result, restored)
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	var names []string
+
+	for name := range triggerMap {
+		if !matched[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		configured := triggerMap[name]
+
+		if configured["type"].(string) == "" || configured["event_listener"].(string) == "" {
+			return nil, nil, nil, fmt.Errorf("trigger '%s' does not exist, `type` and `event_listener` are required to add it", name)
+		}
+
+		trigger := expandTektonPipelineTrigger(expandOverridesTriggerMap(configured))
+		trigger.ID = getStringPtr(uuid.NewString())
+		result = append(result, trigger)
+		updatedNewTriggers = append(updatedNewTriggers, *trigger.ID)
+	}
+
+	return result, updatedOriginals, updatedNewTriggers, nil
+}
+
+// overrides trigger block uses slightly different attribute names than opentoolchain_tekton_pipeline trigger block
+func expandOverridesTriggerMap(trigger map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	for k, v := range trigger {
+		result[k] = v
+	}
+
+	result["github_integration_id"] = trigger["github_integration_guid"]
+
+	return result
+}
+
+// updates trigger enabled status and branch or pattern, branch takes priority if both are set
+func applyTriggerOverride(t *oc.TektonPipelineTrigger, enabled bool, branch string, pattern string) {
+	t.Disabled = getBoolPtr(!enabled)
+
+	// we should not be setting branch or pattern for non-scm triggers
+	// this should be sufficient check
+	if t.ScmSource == nil {
+		if branch != "" || pattern != "" {
+			log.Printf("[WARN] Trying to set branch/pattern for non scm trigger: %s", *t.Name)
+		}
+		return
+	}
+
+	if pattern != "" && branch != "" {
+		log.Printf("[WARN] Both trigger (%s) branch and pattern were set, branch setting will be used: %s", *t.Name, branch)
+	}
+
+	// copy to avoid modifying shared scm source
+	scm := *t.ScmSource
+
+	if pattern != "" {
+		scm.Pattern = getStringPtr(pattern)
+		scm.Branch = nil
+	}
+
+	if branch != "" {
+		scm.Branch = getStringPtr(branch)
+		scm.Pattern = nil
+	}
+
+	t.ScmSource = &scm
+}
+
+// overrides resource does not know toolchain ID until pipeline is read
+func getOverridesSecretReferencesToolchainID(ctx context.Context, d *schema.ResourceDiff, config *ProviderConfig) (string, error) {
+	if toolchainID, ok := d.Get("toolchain_guid").(string); ok && toolchainID != "" {
+		return toolchainID, nil
+	}
+
+	if !d.NewValueKnown("guid") || !d.NewValueKnown("env_id") {
+		return "", nil
+	}
+
+	guid := d.Get("guid").(string)
+	envID := d.Get("env_id").(string)
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	pipeline, _, err := config.OTClient.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
+		GUID:   &guid,
+		Region: &region,
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error reading tekton pipeline: %s", err)
+	}
+
+	return getStringValue(pipeline.ToolchainID), nil
+}
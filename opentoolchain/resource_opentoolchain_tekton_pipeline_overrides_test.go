@@ -0,0 +1,67 @@
+package opentoolchain
+
+import (
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func getOverridesTrigger(name string, enabled bool, branch string, pattern string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                    name,
+		"enabled":                 enabled,
+		"branch":                  branch,
+		"pattern":                 pattern,
+		"type":                    "",
+		"event_listener":          "",
+		"github_integration_guid": "",
+		"github_url":              "",
+		"on_push":                 false,
+		"on_pull_request":         false,
+		"on_pull_request_closed":  false,
+	}
+}
+
+func TestMakeTriggerOverridesPatch(t *testing.T) {
+	current := []oc.TektonPipelineTrigger{
+		{ID: getStringPtr("1"), Name: getStringPtr("manual"), Type: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+		{ID: getStringPtr("2"), Name: getStringPtr("pr"), Type: getStringPtr("scm"), Disabled: getBoolPtr(true), ScmSource: &oc.TektonPipelineTriggerScmSource{Branch: getStringPtr("master")}},
+	}
+
+	// override existing trigger and add new one
+	newTrigger := getOverridesTrigger("feature", true, "", "feature/*")
+	newTrigger["type"] = "scm"
+	newTrigger["event_listener"] = "ci-git-push"
+	newTrigger["on_push"] = true
+
+	patch, originals, newTriggers, err := makeTriggerOverridesPatch([]interface{}{
+		getOverridesTrigger("pr", true, "develop", ""),
+		newTrigger,
+	}, current, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, patch, 3)
+	assert.Equal(t, current[0], patch[0])
+	assert.Equal(t, false, *patch[1].Disabled)
+	assert.Equal(t, "develop", *patch[1].ScmSource.Branch)
+	assert.Equal(t, "master", *current[1].ScmSource.Branch) // current triggers are not modified
+	assert.Equal(t, "feature", *patch[2].Name)
+	assert.Equal(t, "feature/*", *patch[2].ScmSource.Pattern)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "2", "name": "pr", "enabled": false, "branch": "master", "pattern": ""},
+	}, originals)
+	assert.Equal(t, []interface{}{*patch[2].ID}, newTriggers)
+
+	// removing all triggers from configuration restores originals and deletes new triggers
+	restored, originals, newTriggers, err := makeTriggerOverridesPatch(nil, patch, originals, newTriggers)
+
+	assert.NoError(t, err)
+	assert.Nil(t, originals)
+	assert.Nil(t, newTriggers)
+	assert.Equal(t, current, restored)
+
+	// new trigger without type and event listener
+	_, _, _, err = makeTriggerOverridesPatch([]interface{}{getOverridesTrigger("missing", true, "", "")}, current, nil, nil)
+
+	assert.Error(t, err)
+}
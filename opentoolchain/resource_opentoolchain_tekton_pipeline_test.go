@@ -0,0 +1,23 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExpandTektonPipelineWorker(t *testing.T) {
+	worker := expandTektonPipelineWorker("public")
+
+	assert.Equal(t, "public", *worker.WorkerID)
+	assert.Equal(t, "public", *worker.WorkerType)
+	assert.Equal(t, tektonPublicWorkerName, *worker.WorkerName)
+
+	worker = expandTektonPipelineWorker("")
+	assert.Equal(t, "public", *worker.WorkerID)
+
+	worker = expandTektonPipelineWorker("b7ae3a37-0d3a-4ab5-b7e3-e2d0ed0a4a0c")
+
+	assert.Equal(t, "b7ae3a37-0d3a-4ab5-b7e3-e2d0ed0a4a0c", *worker.WorkerID)
+	assert.Equal(t, "private", *worker.WorkerType)
+	assert.Nil(t, worker.WorkerName)
+}
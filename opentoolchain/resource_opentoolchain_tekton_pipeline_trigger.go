@@ -0,0 +1,313 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceOpenToolchainTektonPipelineTrigger() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage single tekton pipeline trigger, other pipeline triggers are left untouched. Do not use this with pipelines that have `trigger` blocks in `opentoolchain_tekton_pipeline` or you may get inconsistent results (WARN: using undocumented APIs)",
+		CreateContext: resourceOpenToolchainTektonPipelineTriggerCreate,
+		ReadContext:   resourceOpenToolchainTektonPipelineTriggerRead,
+		DeleteContext: resourceOpenToolchainTektonPipelineTriggerDelete,
+		UpdateContext: resourceOpenToolchainTektonPipelineTriggerUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOpenToolchainTektonPipelineTriggerImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": {
+				Description: "The tekton pipeline `guid`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"env_id": {
+				Description: "Environment ID, example: `ibm:yp:us-south`",
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+			},
+			"trigger_id": {
+				Description: "Trigger ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"enabled": {
+				Description: "`true` if trigger should be active",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"github_integration_id": {
+				Description: "Github or GitLab integration ID",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"github_url": {
+				Description: "Github or GitLab repository URL",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"scm_type": {
+				Description:  "Repository integration type, set to `GitLab` when `github_integration_id` references GitLab integration",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      tektonScmTypeGithub,
+				ValidateFunc: validation.StringInSlice(tektonScmTypes, false),
+			},
+			"name": {
+				Description: "Trigger name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"event_listener": {
+				Description: "Event Listener name (from .tekton pipeline definition)",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"on_pull_request": {
+				Description: "Trigger when pull request is opened or updated",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"on_pull_request_closed": {
+				Description: "Trigger when pull request is closed",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"on_push": {
+				Description: "Trigger when commit is pushed",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"branch": {
+				Description: "GitHub branch",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"pattern": {
+				Description: "GitHub branch pattern, if `branch` is not specified, otherwise setting is ignored",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": {
+				Description:  "Trigger type",
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"scm", "manual"}, false),
+				Required:     true,
+			},
+		},
+	}
+}
+
+func resourceOpenToolchainTektonPipelineTriggerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pipelineID := d.Get("pipeline_id").(string)
+	envID := d.Get("env_id").(string)
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	config := m.(*ProviderConfig)
+
+	trigger := expandTektonPipelineTrigger(getTektonPipelineTriggerMap(d))
+	trigger.ID = getStringPtr(uuid.NewString())
+
+	// PATCH replaces the whole list, so existing triggers are read first
+	_, err := patchTektonPipeline(ctx, config, pipelineID, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		return &oc.PatchTektonPipelineOptions{
+			Triggers: upsertTektonPipelineTrigger(pipeline.Triggers, trigger),
+		}, nil
+	})
+
+	if err != nil {
+		return diag.Errorf("Failed creating tekton pipeline trigger: %s", err)
+	}
+
+	d.Set("trigger_id", *trigger.ID)
+	d.SetId(fmt.Sprintf("%s/%s/%s", *trigger.ID, pipelineID, envID))
+
+	return resourceOpenToolchainTektonPipelineTriggerRead(ctx, d, m)
+}
+
+// trigger API is only reachable through its pipeline and there is no way to find a pipeline by trigger ID,
+// so bare trigger IDs are rejected instead of failing on first read
+func resourceOpenToolchainTektonPipelineTriggerImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), "/")
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		return nil, fmt.Errorf("incorrect ID %s: expected format is triggerID/pipelineID/envID, triggers can only be read through their pipeline so trigger ID alone is not enough", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceOpenToolchainTektonPipelineTriggerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Id()
+	idParts := strings.Split(id, "/")
+
+	if len(idParts) < 3 {
+		return diag.Errorf("Incorrect ID %s: ID should be a combination of triggerID/pipelineID/envID", d.Id())
+	}
+
+	triggerID := idParts[0]
+	pipelineID := idParts[1]
+	envID := idParts[2]
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	d.Set("trigger_id", triggerID)
+	d.Set("pipeline_id", pipelineID)
+	d.Set("env_id", envID)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	pipeline, resp, err := c.GetTektonPipelineWithContext(ctx, &oc.GetTektonPipelineOptions{
+		GUID:   &pipelineID,
+		Region: &region,
+	})
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("[WARN] Tekton pipeline '%s' is not found, removing trigger '%s' from state", pipelineID, triggerID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("Error reading tekton pipeline: %s", err)
+	}
+
+	var trigger *oc.TektonPipelineTrigger
+
+	for i, t := range pipeline.Triggers {
+		if t.ID != nil && *t.ID == triggerID {
+			trigger = &pipeline.Triggers[i]
+			break
+		}
+	}
+
+	if trigger == nil {
+		log.Printf("[WARN] Tekton pipeline trigger '%s' is not found, removing it from state", triggerID)
+		d.SetId("")
+		return nil
+	}
+
+	for k, v := range flattenTektonPipelineTrigger(*trigger) {
+		if err := d.Set(k, v); err != nil {
+			return diag.Errorf("Error setting tekton pipeline trigger %s: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceOpenToolchainTektonPipelineTriggerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	triggerID := d.Get("trigger_id").(string)
+	pipelineID := d.Get("pipeline_id").(string)
+	envID := d.Get("env_id").(string)
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	config := m.(*ProviderConfig)
+
+	trigger := expandTektonPipelineTrigger(getTektonPipelineTriggerMap(d))
+	trigger.ID = &triggerID
+
+	_, err := patchTektonPipeline(ctx, config, pipelineID, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		return &oc.PatchTektonPipelineOptions{
+			Triggers: upsertTektonPipelineTrigger(pipeline.Triggers, trigger),
+		}, nil
+	})
+
+	if err != nil {
+		return diag.Errorf("Failed updating tekton pipeline trigger: %s", err)
+	}
+
+	return resourceOpenToolchainTektonPipelineTriggerRead(ctx, d, m)
+}
+
+func resourceOpenToolchainTektonPipelineTriggerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	triggerID := d.Get("trigger_id").(string)
+	pipelineID := d.Get("pipeline_id").(string)
+	envID := d.Get("env_id").(string)
+
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	config := m.(*ProviderConfig)
+
+	_, err := patchTektonPipeline(ctx, config, pipelineID, region, func(pipeline *oc.TektonPipeline) (*oc.PatchTektonPipelineOptions, error) {
+		return &oc.PatchTektonPipelineOptions{
+			Triggers: removeTektonPipelineTrigger(pipeline.Triggers, triggerID),
+		}, nil
+	})
+
+	if err != nil {
+		return diag.Errorf("Failed deleting tekton pipeline trigger: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// collect trigger attributes in the same shape as opentoolchain_tekton_pipeline trigger block
+func getTektonPipelineTriggerMap(d *schema.ResourceData) map[string]interface{} {
+	trigger := make(map[string]interface{})
+
+	for _, k := range []string{"enabled", "github_integration_id", "github_url", "scm_type", "name", "event_listener", "on_pull_request", "on_pull_request_closed", "on_push", "branch", "pattern", "type"} {
+		trigger[k] = d.Get(k)
+	}
+
+	return trigger
+}
+
+// replaces trigger with matching ID, or appends it if there is none
+func upsertTektonPipelineTrigger(triggers []oc.TektonPipelineTrigger, trigger oc.TektonPipelineTrigger) []oc.TektonPipelineTrigger {
+	result := make([]oc.TektonPipelineTrigger, 0, len(triggers)+1)
+	found := false
+
+	for _, t := range triggers {
+		if t.ID != nil && *t.ID == *trigger.ID {
+			result = append(result, trigger)
+			found = true
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	if !found {
+		result = append(result, trigger)
+	}
+
+	return result
+}
+
+// removes trigger with matching ID, result is never nil so that PATCH clears the last trigger as well
+func removeTektonPipelineTrigger(triggers []oc.TektonPipelineTrigger, triggerID string) []oc.TektonPipelineTrigger {
+	result := make([]oc.TektonPipelineTrigger, 0, len(triggers))
+
+	for _, t := range triggers {
+		if t.ID != nil && *t.ID == triggerID {
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	return result
+}
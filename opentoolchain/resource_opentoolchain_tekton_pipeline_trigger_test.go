@@ -0,0 +1,109 @@
+package opentoolchain
+
+import (
+	"context"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUpsertTektonPipelineTrigger(t *testing.T) {
+	testcases := []struct {
+		triggers []oc.TektonPipelineTrigger
+		trigger  oc.TektonPipelineTrigger
+		expected []oc.TektonPipelineTrigger
+	}{
+		{
+			triggers: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+				{ID: getStringPtr("2"), Name: getStringPtr("pr"), Disabled: getBoolPtr(false)},
+			},
+			trigger: oc.TektonPipelineTrigger{ID: getStringPtr("2"), Name: getStringPtr("pr"), Disabled: getBoolPtr(true)},
+			expected: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+				{ID: getStringPtr("2"), Name: getStringPtr("pr"), Disabled: getBoolPtr(true)},
+			},
+		},
+		{
+			triggers: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+			},
+			trigger: oc.TektonPipelineTrigger{ID: getStringPtr("3"), Name: getStringPtr("feature"), Disabled: getBoolPtr(false)},
+			expected: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual"), Disabled: getBoolPtr(false)},
+				{ID: getStringPtr("3"), Name: getStringPtr("feature"), Disabled: getBoolPtr(false)},
+			},
+		},
+		{
+			triggers: nil,
+			trigger:  oc.TektonPipelineTrigger{ID: getStringPtr("3"), Name: getStringPtr("feature"), Disabled: getBoolPtr(false)},
+			expected: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("3"), Name: getStringPtr("feature"), Disabled: getBoolPtr(false)},
+			},
+		},
+	}
+
+	for _, c := range testcases {
+		actual := upsertTektonPipelineTrigger(c.triggers, c.trigger)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestRemoveTektonPipelineTrigger(t *testing.T) {
+	testcases := []struct {
+		triggers  []oc.TektonPipelineTrigger
+		triggerID string
+		expected  []oc.TektonPipelineTrigger
+	}{
+		{
+			triggers: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual")},
+				{ID: getStringPtr("2"), Name: getStringPtr("pr")},
+			},
+			triggerID: "1",
+			expected: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("2"), Name: getStringPtr("pr")},
+			},
+		},
+		{
+			triggers: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual")},
+			},
+			triggerID: "1",
+			expected:  []oc.TektonPipelineTrigger{}, // empty list, not nil, so PATCH removes the last trigger
+		},
+		{
+			triggers: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual")},
+			},
+			triggerID: "missing",
+			expected: []oc.TektonPipelineTrigger{
+				{ID: getStringPtr("1"), Name: getStringPtr("manual")},
+			},
+		},
+	}
+
+	for _, c := range testcases {
+		actual := removeTektonPipelineTrigger(c.triggers, c.triggerID)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestImportTektonPipelineTrigger(t *testing.T) {
+	r := resourceOpenToolchainTektonPipelineTrigger()
+
+	for _, id := range []string{"trigger", "trigger/pipeline", "trigger//ibm:yp:us-south"} {
+		d := r.TestResourceData()
+		d.SetId(id)
+
+		_, err := r.Importer.StateContext(context.Background(), d, nil)
+		assert.EqualError(t, err, "incorrect ID "+id+": expected format is triggerID/pipelineID/envID, triggers can only be read through their pipeline so trigger ID alone is not enough")
+	}
+
+	d := r.TestResourceData()
+	d.SetId("trigger/pipeline/ibm:yp:us-south")
+
+	result, err := r.Importer.StateContext(context.Background(), d, nil)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+}
@@ -128,6 +128,12 @@ func resourceOpenToolchainToolchain() *schema.Resource {
 				},
 				Optional: true,
 			},
+			"lifecycle_messaging_webhook_id": {
+				Description: "Toolchain lifecycle messaging target webhook ID, for example `webhook_id` of an Event Notifications integration (referencing integration bound to the same toolchain creates a dependency cycle, set it after integration is created)",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -168,7 +174,10 @@ func resourceOpenToolchainToolchainRead(ctx context.Context, d *schema.ResourceD
 	d.Set("crn", *toolchain.CRN)
 	d.Set("services", flattenToolchainServices(toolchain.Services))
 	//d.Set("template", flattenToolchainTemplate(toolchain.Template))
-	// d.Set("lifecycle_messaging_webhook_id", *toolchain.LifecycleMessagingWebhookID)
+
+	if toolchain.LifecycleMessagingWebhookID != nil {
+		d.Set("lifecycle_messaging_webhook_id", *toolchain.LifecycleMessagingWebhookID)
+	}
 
 	u, err := url.Parse("https://cloud.ibm.com")
 
@@ -285,6 +294,16 @@ func resourceOpenToolchainToolchainCreate(ctx context.Context, d *schema.Resourc
 		}
 	}
 
+	if webhookID, ok := d.GetOk("lifecycle_messaging_webhook_id"); ok {
+		err := patchToolchain(ctx, c, region, guid, map[string]interface{}{
+			"lifecycle_messaging_webhook_id": webhookID.(string),
+		})
+
+		if err != nil {
+			return diag.Errorf("Error setting toolchain lifecycle messaging webhook: %s", err)
+		}
+	}
+
 	tags := expandStringList(d.Get("tags").(*schema.Set).List())
 	crn, err := getCRN(ctx, d, m)
 
@@ -404,6 +423,16 @@ func resourceOpenToolchainToolchainUpdate(ctx context.Context, d *schema.Resourc
 		}
 	}
 
+	if d.HasChange("lifecycle_messaging_webhook_id") {
+		err := patchToolchain(ctx, c, region, guid, map[string]interface{}{
+			"lifecycle_messaging_webhook_id": d.Get("lifecycle_messaging_webhook_id").(string),
+		})
+
+		if err != nil {
+			return diag.Errorf("Error updating toolchain lifecycle messaging webhook: %s", err)
+		}
+	}
+
 	if d.HasChange("tags") {
 		o, n := d.GetChange("tags")
 
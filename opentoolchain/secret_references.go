@@ -0,0 +1,253 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// secrets can reference values stored in vault-type toolchain integrations using
+// `{vault::vault_integration_name.VAULT_KEY}` syntax
+const secretReferencePrefix = "{vault::"
+
+var secretReferenceRegexp = regexp.MustCompile(`^\{vault::([^.{}]+)\.([^{}]+)\}$`)
+
+// service types that can be used as secret stores in secret references
+var secretStoreServiceTypes = map[string]bool{
+	keyProtectIntegrationServiceType:     true,
+	secretsManagerIntegrationServiceType: true,
+	hashicorpVaultIntegrationServiceType: true,
+}
+
+// getSecretReferencePrefix returns secret reference prefix for vault integration with given name,
+// secret key and closing bracket must be appended to it: `${prefix}VAULT_KEY}`
+func getSecretReferencePrefix(integrationName string) string {
+	return fmt.Sprintf("%s%s.", secretReferencePrefix, integrationName)
+}
+
+type secretReference struct {
+	IntegrationName string
+	Key             string
+}
+
+// parseSecretReference returns nil if value is not a secret reference,
+// error if it starts like one but does not match `{vault::vault_integration_name.VAULT_KEY}` syntax
+func parseSecretReference(value string) (*secretReference, error) {
+	if !strings.HasPrefix(value, secretReferencePrefix) {
+		return nil, nil
+	}
+
+	match := secretReferenceRegexp.FindStringSubmatch(value)
+
+	if match == nil {
+		return nil, fmt.Errorf("invalid secret reference, expected format is {vault::vault_integration_name.VAULT_KEY}")
+	}
+
+	return &secretReference{
+		IntegrationName: match[1],
+		Key:             match[2],
+	}, nil
+}
+
+// validateSecretReference is a ValidateFunc for string secrets
+func validateSecretReference(v interface{}, k string) (warnings []string, errors []error) {
+	if _, err := parseSecretReference(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
+	}
+
+	return warnings, errors
+}
+
+// validateSecretReferenceMap is a ValidateFunc for secret maps
+func validateSecretReferenceMap(v interface{}, k string) (warnings []string, errors []error) {
+	for key, value := range v.(map[string]interface{}) {
+		if s, ok := value.(string); ok {
+			if _, err := parseSecretReference(s); err != nil {
+				errors = append(errors, fmt.Errorf("%s.%s: %s", k, key, err))
+			}
+		}
+	}
+
+	return warnings, errors
+}
+
+// resolveSecretReferences matches secret references against toolchain secret store integrations,
+// returns a list of references sorted by property name, suitable for `secret_references` attribute
+func resolveSecretReferences(ctx context.Context, c *oc.OpenToolchainV1, toolchainID string, region string, secrets map[string]interface{}) ([]interface{}, error) {
+	references := make(map[string]*secretReference)
+	var properties []string
+
+	for k, v := range secrets {
+		ref, err := parseSecretReference(v.(string))
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", k, err)
+		}
+
+		if ref != nil {
+			references[k] = ref
+			properties = append(properties, k)
+		}
+	}
+
+	result := make([]interface{}, 0, len(properties))
+
+	if len(properties) == 0 {
+		return result, nil
+	}
+
+	response, _, err := c.GetToolchainWithContext(ctx, &oc.GetToolchainOptions{
+		GUID:    &toolchainID,
+		Region:  &region,
+		Include: getStringPtr("fields,services"),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading toolchain: %s", err)
+	}
+
+	if len(response.Items) == 0 {
+		return nil, fmt.Errorf("no toolchain found with GUID: %s", toolchainID)
+	}
+
+	stores := make(map[string]oc.Service)
+
+	for _, svc := range response.Items[0].Services {
+		if svc.ServiceID == nil || !secretStoreServiceTypes[*svc.ServiceID] || svc.Parameters == nil {
+			continue
+		}
+
+		if name, ok := svc.Parameters["name"].(string); ok {
+			stores[name] = svc
+		}
+	}
+
+	sort.Strings(properties)
+
+	for _, k := range properties {
+		ref := references[k]
+		store, ok := stores[ref.IntegrationName]
+
+		if !ok {
+			return nil, fmt.Errorf("%s: vault integration '%s' does not exist in toolchain %s", k, ref.IntegrationName, toolchainID)
+		}
+
+		result = append(result, map[string]interface{}{
+			"property":         k,
+			"integration_name": ref.IntegrationName,
+			"integration_id":   getStringValue(store.InstanceID),
+			"service_id":       *store.ServiceID,
+			"secret_key":       ref.Key,
+		})
+	}
+
+	return result, nil
+}
+
+// secretReferencesSchema describes computed `secret_references` attribute
+func secretReferencesSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Secret properties that reference vault integrations",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"property": {
+					Description: "Secret property name",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"integration_name": {
+					Description: "Referenced vault integration name",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"integration_id": {
+					Description: "Referenced vault integration `guid`",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"service_id": {
+					Description: "Referenced vault integration service type, for example: `keyprotect`",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"secret_key": {
+					Description: "Secret key inside vault integration",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// customizeSecretReferencesDiff validates secret references against toolchain integrations during planning,
+// getToolchainID returns empty string if toolchain is not known yet. If referencesKey is not empty,
+// resolved references are planned as its new value.
+func customizeSecretReferencesDiff(key string, referencesKey string, getToolchainID func(ctx context.Context, d *schema.ResourceDiff, config *ProviderConfig) (string, error)) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		config := m.(*ProviderConfig)
+
+		if !d.NewValueKnown(key) {
+			if referencesKey != "" {
+				return d.SetNewComputed(referencesKey)
+			}
+
+			return nil
+		}
+
+		toolchainID, err := getToolchainID(ctx, d, config)
+
+		if err != nil {
+			return err
+		}
+
+		if toolchainID == "" || !d.NewValueKnown("env_id") {
+			if referencesKey != "" && d.HasChange(key) {
+				return d.SetNewComputed(referencesKey)
+			}
+
+			return nil
+		}
+
+		envID := d.Get("env_id").(string)
+		envIDParts := strings.Split(envID, ":")
+		region := envIDParts[len(envIDParts)-1]
+
+		var secrets map[string]interface{}
+
+		switch v := d.Get(key).(type) {
+		case map[string]interface{}:
+			secrets = v
+		case string:
+			secrets = map[string]interface{}{key: v}
+		}
+
+		references, err := resolveSecretReferences(ctx, config.OTClient, toolchainID, region, secrets)
+
+		if err != nil {
+			return err
+		}
+
+		if referencesKey != "" {
+			return d.SetNew(referencesKey, references)
+		}
+
+		return nil
+	}
+}
+
+// returns toolchain_id attribute value if it is known
+func getSecretReferencesToolchainID(ctx context.Context, d *schema.ResourceDiff, config *ProviderConfig) (string, error) {
+	if !d.NewValueKnown("toolchain_id") {
+		return "", nil
+	}
+
+	return d.Get("toolchain_id").(string), nil
+}
@@ -0,0 +1,50 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	ref, err := parseSecretReference("{vault::kp-vault.API_KEY}")
+	assert.NoError(t, err)
+	assert.Equal(t, &secretReference{IntegrationName: "kp-vault", Key: "API_KEY"}, ref)
+
+	// dots are allowed in secret keys
+	ref, err = parseSecretReference("{vault::kp-vault.group.API_KEY}")
+	assert.NoError(t, err)
+	assert.Equal(t, &secretReference{IntegrationName: "kp-vault", Key: "group.API_KEY"}, ref)
+
+	// plain secret values are not references
+	ref, err = parseSecretReference("plain secret")
+	assert.NoError(t, err)
+	assert.Nil(t, ref)
+
+	for _, value := range []string{
+		"{vault::kp-vault}",
+		"{vault::kp-vault.}",
+		"{vault::.API_KEY}",
+		"{vault::kp-vault.API_KEY",
+		"{vault::kp-vault.API_KEY} ",
+	} {
+		_, err = parseSecretReference(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestValidateSecretReferenceMap(t *testing.T) {
+	_, errs := validateSecretReferenceMap(map[string]interface{}{
+		"VALID":   "{vault::kp-vault.API_KEY}",
+		"PLAIN":   "secret",
+		"INVALID": "{vault::kp-vault}",
+	}, "secret_env")
+
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "secret_env.INVALID")
+}
+
+func TestGetSecretReferencePrefix(t *testing.T) {
+	ref, err := parseSecretReference(getSecretReferencePrefix("sm-vault") + "API_KEY}")
+	assert.NoError(t, err)
+	assert.Equal(t, &secretReference{IntegrationName: "sm-vault", Key: "API_KEY"}, ref)
+}
@@ -0,0 +1,172 @@
+package opentoolchain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Opentoolchain API never returns actual secret values, only encrypted strings that can change
+// whenever the service decides to re-encrypt them. Instead of comparing ciphertexts, we keep
+// salted HMAC of each plaintext secret in state and compare planned values against it,
+// encrypted value returned by the API is only used as a signal that secret was modified outside of Terraform.
+
+const secretSaltLength = 16
+
+// hashSecret returns salted HMAC-SHA256 of the secret value, in `<salt>:<hmac>` format (hex encoded)
+func hashSecret(value string) string {
+	salt := make([]byte, secretSaltLength)
+
+	if _, err := rand.Read(salt); err != nil {
+		// should never happen, empty hash forces secret update on next apply
+		log.Printf("[WARN] Unable to generate secret salt: %s", err)
+		return ""
+	}
+
+	return hex.EncodeToString(salt) + ":" + hex.EncodeToString(computeSecretHMAC(salt, value))
+}
+
+// secretHashMatches checks if secret value matches hash generated by hashSecret
+func secretHashMatches(hash string, value string) bool {
+	parts := strings.SplitN(hash, ":", 2)
+
+	if len(parts) != 2 {
+		return false
+	}
+
+	salt, err := hex.DecodeString(parts[0])
+
+	if err != nil {
+		return false
+	}
+
+	expected, err := hex.DecodeString(parts[1])
+
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(expected, computeSecretHMAC(salt, value))
+}
+
+func computeSecretHMAC(salt []byte, value string) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// hashSecretMap returns a map of salted secret hashes for every secret value
+func hashSecretMap(secrets map[string]interface{}) map[string]string {
+	result := make(map[string]string)
+
+	for k, v := range secrets {
+		result[k] = hashSecret(v.(string))
+	}
+
+	return result
+}
+
+// readSecretDrift compares encrypted secret value returned by the API with the one saved in state,
+// if it changed, secret was modified outside of Terraform and saved hash is cleared to force an update
+func readSecretDrift(d *schema.ResourceData, encryptedKey string, hashKey string, encrypted string) {
+	current := d.Get(encryptedKey).(string)
+
+	if current != "" && current != encrypted {
+		log.Printf("[WARN] Encrypted value of %s changed, it was modified outside of Terraform", encryptedKey)
+		d.Set(hashKey, "")
+	}
+
+	d.Set(encryptedKey, encrypted)
+}
+
+// readSecretMapDrift is the same as readSecretDrift, for secret maps, hashes are cleared for any secret
+// that has changed its encrypted value or no longer exists
+func readSecretMapDrift(d *schema.ResourceData, encryptedKey string, hashKey string, encrypted map[string]string) {
+	current := d.Get(encryptedKey).(map[string]interface{})
+	hashes := d.Get(hashKey).(map[string]interface{})
+
+	for k := range hashes {
+		value, ok := encrypted[k]
+
+		if !ok || (current[k] != nil && current[k].(string) != value) {
+			log.Printf("[WARN] Encrypted value of %s.%s changed, it was modified outside of Terraform", encryptedKey, k)
+			delete(hashes, k)
+		}
+	}
+
+	d.Set(hashKey, hashes)
+	d.Set(encryptedKey, encrypted)
+}
+
+// secretChanged reports whether secret needs to be sent to the API during update
+func secretChanged(d *schema.ResourceData, key string, hashKey string) bool {
+	if d.HasChange(key) {
+		return true
+	}
+
+	// computed hash can be marked as unknown by CustomizeDiff, check value saved in state instead
+	hash, _ := d.GetChange(hashKey)
+	return !secretHashMatches(hash.(string), d.Get(key).(string))
+}
+
+// secretMapChanged is the same as secretChanged, for secret maps
+func secretMapChanged(d *schema.ResourceData, key string, hashKey string) bool {
+	if d.HasChange(key) {
+		return true
+	}
+
+	hashes, _ := d.GetChange(hashKey)
+	return secretMapHashesOutdated(d.Get(key).(map[string]interface{}), hashes.(map[string]interface{}))
+}
+
+func secretMapHashesOutdated(secrets map[string]interface{}, hashes map[string]interface{}) bool {
+	if len(secrets) != len(hashes) {
+		return true
+	}
+
+	for k, v := range secrets {
+		hash, ok := hashes[k].(string)
+
+		if !ok || !secretHashMatches(hash, v.(string)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// customizeSecretHashDiff forces an update if planned secret value does not match saved hash
+func customizeSecretHashDiff(key string, hashKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" || !d.NewValueKnown(key) {
+			return nil
+		}
+
+		if !secretHashMatches(d.Get(hashKey).(string), d.Get(key).(string)) {
+			return d.SetNewComputed(hashKey)
+		}
+
+		return nil
+	}
+}
+
+// customizeSecretMapHashDiff is the same as customizeSecretHashDiff, for secret maps
+func customizeSecretMapHashDiff(key string, hashKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" || !d.NewValueKnown(key) {
+			return nil
+		}
+
+		if secretMapHashesOutdated(d.Get(key).(map[string]interface{}), d.Get(hashKey).(map[string]interface{})) {
+			return d.SetNewComputed(hashKey)
+		}
+
+		return nil
+	}
+}
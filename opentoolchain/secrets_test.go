@@ -0,0 +1,44 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHashSecret(t *testing.T) {
+	hash := hashSecret("secret value")
+
+	assert.True(t, secretHashMatches(hash, "secret value"))
+	assert.False(t, secretHashMatches(hash, "other value"))
+	assert.NotEqual(t, hash, hashSecret("secret value"), "hashes should be salted")
+
+	// invalid or missing hashes never match
+	assert.False(t, secretHashMatches("", ""))
+	assert.False(t, secretHashMatches("abc", "secret value"))
+	assert.False(t, secretHashMatches("xyz:abc", "secret value"))
+}
+
+func TestSecretMapHashesOutdated(t *testing.T) {
+	secrets := map[string]interface{}{
+		"API_KEY": "key",
+		"TOKEN":   "token",
+	}
+
+	hashes := map[string]interface{}{}
+
+	for k, v := range hashSecretMap(secrets) {
+		hashes[k] = v
+	}
+
+	assert.False(t, secretMapHashesOutdated(secrets, hashes))
+
+	// modified value
+	assert.True(t, secretMapHashesOutdated(map[string]interface{}{"API_KEY": "new key", "TOKEN": "token"}, hashes))
+
+	// new secret
+	assert.True(t, secretMapHashesOutdated(map[string]interface{}{"API_KEY": "key", "TOKEN": "token", "NEW": "value"}, hashes))
+
+	// hash was cleared after detecting outside modification
+	delete(hashes, "TOKEN")
+	assert.True(t, secretMapHashesOutdated(secrets, hashes))
+}
@@ -0,0 +1,271 @@
+package opentoolchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+)
+
+// SDK service instance parameter structs only cover a subset of parameters used by integrations,
+// these helpers send raw parameter maps to the same (undocumented) endpoints
+
+func createServiceInstance(ctx context.Context, c *oc.OpenToolchainV1, toolchainID string, envID string, serviceID string, parameters map[string]interface{}) error {
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = c.GetEnableGzipCompression()
+
+	_, err := builder.ResolveRequestURL(c.Service.Options.URL, `/cloud.ibm.com/devops/service_instances`, nil)
+
+	if err != nil {
+		return err
+	}
+
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Content-Type", "application/json")
+	builder.AddQuery("env_id", envID)
+
+	_, err = builder.SetBodyContentJSON(map[string]interface{}{
+		"toolchainId": toolchainID,
+		"serviceId":   serviceID,
+		"parameters":  parameters,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	request, err := builder.Build()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Service.Request(request, nil)
+	return err
+}
+
+func patchServiceInstance(ctx context.Context, c *oc.OpenToolchainV1, toolchainID string, envID string, serviceID string, guid string, parameters map[string]interface{}) error {
+	builder := core.NewRequestBuilder(core.PATCH)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = c.GetEnableGzipCompression()
+
+	_, err := builder.ResolveRequestURL(c.Service.Options.URL, `/cloud.ibm.com/devops/service_instances/{guid}`, map[string]string{
+		"guid": guid,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	builder.AddHeader("Content-Type", "application/json")
+	builder.AddQuery("env_id", envID)
+
+	_, err = builder.SetBodyContentJSON(map[string]interface{}{
+		"toolchainId": toolchainID,
+		"service_id":  serviceID,
+		"parameters":  parameters,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	request, err := builder.Build()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Service.Request(request, nil)
+	return err
+}
+
+// getToolchainServices returns all service instances attached to toolchain
+func getToolchainServices(ctx context.Context, c *oc.OpenToolchainV1, toolchainID string, region string) ([]oc.Service, error) {
+	response, _, err := c.GetToolchainWithContext(ctx, &oc.GetToolchainOptions{
+		GUID:    &toolchainID,
+		Region:  &region,
+		Include: getStringPtr("fields,services"),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading toolchain: %s", err)
+	}
+
+	if len(response.Items) == 0 {
+		return nil, fmt.Errorf("no toolchain found with GUID: %s", toolchainID)
+	}
+
+	return response.Items[0].Services, nil
+}
+
+// getServiceInstanceIDs returns IDs of all toolchain service instances with matching service type
+func getServiceInstanceIDs(ctx context.Context, c *oc.OpenToolchainV1, toolchainID string, region string, serviceID string) (map[string]bool, error) {
+	services, err := getToolchainServices(ctx, c, toolchainID, region)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool)
+
+	for _, svc := range services {
+		if svc.ServiceID != nil && *svc.ServiceID == serviceID && svc.InstanceID != nil {
+			result[*svc.InstanceID] = true
+		}
+	}
+
+	return result, nil
+}
+
+// createServiceInstanceWithID creates service instance and returns its GUID, API does not return it,
+// so we compare toolchain service instances before and after creation. Caller must hold toolchain lock.
+func createServiceInstanceWithID(ctx context.Context, c *oc.OpenToolchainV1, toolchainID string, envID string, serviceID string, parameters map[string]interface{}) (string, error) {
+	envIDParts := strings.Split(envID, ":")
+	region := envIDParts[len(envIDParts)-1]
+
+	before, err := getServiceInstanceIDs(ctx, c, toolchainID, region, serviceID)
+
+	if err != nil {
+		return "", err
+	}
+
+	err = createServiceInstance(ctx, c, toolchainID, envID, serviceID, parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	after, err := getServiceInstanceIDs(ctx, c, toolchainID, region, serviceID)
+
+	if err != nil {
+		return "", err
+	}
+
+	var created []string
+
+	for id := range after {
+		if !before[id] {
+			created = append(created, id)
+		}
+	}
+
+	if len(created) != 1 {
+		// no way to cleanup since we don't know integration GUID
+		return "", fmt.Errorf("unable to determine %s integration GUID, found %d new instances", serviceID, len(created))
+	}
+
+	return created[0], nil
+}
+
+// getServiceInstanceWebhookID returns toolchain binding webhook ID for service instance, empty if not bound
+func getServiceInstanceWebhookID(ctx context.Context, c *oc.OpenToolchainV1, toolchainID string, region string, instanceID string) (string, error) {
+	services, err := getToolchainServices(ctx, c, toolchainID, region)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, svc := range services {
+		if svc.InstanceID != nil && *svc.InstanceID == instanceID && svc.ToolchainBinding != nil && svc.ToolchainBinding.WebhookID != nil {
+			return *svc.ToolchainBinding.WebhookID, nil
+		}
+	}
+
+	return "", nil
+}
+
+// patchToolchain updates toolchain fields that are not supported by SDK PatchToolchainOptions
+func patchToolchain(ctx context.Context, c *oc.OpenToolchainV1, region string, guid string, body map[string]interface{}) error {
+	builder := core.NewRequestBuilder(core.PATCH)
+	builder = builder.WithContext(ctx)
+	builder.EnableGzipCompression = c.GetEnableGzipCompression()
+
+	_, err := builder.ResolveRequestURL(c.Service.Options.URL, `/devops-api.{region}.devops.cloud.ibm.com/v1/toolchains/{guid}`, map[string]string{
+		"region": region,
+		"guid":   guid,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	builder.AddHeader("Content-Type", "application/json")
+
+	_, err = builder.SetBodyContentJSON(body)
+
+	if err != nil {
+		return err
+	}
+
+	request, err := builder.Build()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Service.Request(request, nil)
+	return err
+}
+
+// toolchainListPageSize is the number of toolchains requested per page by listToolchains
+const toolchainListPageSize = 100
+
+// listToolchains returns all toolchains in the region, paging through the (undocumented) toolchain list endpoint,
+// results are limited to a resource group if resourceGroupID is not empty
+func listToolchains(ctx context.Context, c *oc.OpenToolchainV1, region string, resourceGroupID string) ([]oc.Toolchain, error) {
+	var result []oc.Toolchain
+
+	for {
+		builder := core.NewRequestBuilder(core.GET)
+		builder = builder.WithContext(ctx)
+		builder.EnableGzipCompression = c.GetEnableGzipCompression()
+
+		_, err := builder.ResolveRequestURL(c.Service.Options.URL, `/devops-api.{region}.devops.cloud.ibm.com/v1/toolchains`, map[string]string{
+			"region": region,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		builder.AddHeader("Accept", "application/json")
+		builder.AddQuery("include", "fields")
+		builder.AddQuery("limit", fmt.Sprint(toolchainListPageSize))
+		builder.AddQuery("offset", fmt.Sprint(len(result)))
+
+		if resourceGroupID != "" {
+			builder.AddQuery("resource_group_id", resourceGroupID)
+		}
+
+		request, err := builder.Build()
+
+		if err != nil {
+			return nil, err
+		}
+
+		var rawResponse map[string]json.RawMessage
+		_, err = c.Service.Request(request, &rawResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var page *oc.ToolchainResponse
+		err = core.UnmarshalModel(rawResponse, "", &page, oc.UnmarshalToolchainResponse)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, page.Items...)
+
+		if len(page.Items) < toolchainListPageSize || (page.TotalResults != nil && len(result) >= int(*page.TotalResults)) {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package opentoolchain
+
+import (
+	"context"
+	"fmt"
+	oc "github.com/dariusbakunas/opentoolchain-go-sdk/opentoolchainv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"sort"
+	"strings"
+)
+
+// serviceInstanceIntegration implements CRUD for simple integrations, where every attribute maps
+// directly to a single service instance parameter
+type serviceInstanceIntegration struct {
+	serviceType string
+	title       string
+	// integration specific attributes, `toolchain_id`, `integration_id`, `env_id` and secret tracking attributes are added automatically
+	schema map[string]*schema.Schema
+	// attribute name to service instance parameter name
+	params map[string]string
+	// sensitive attribute name to service instance parameter name, each secret gets `encrypted_<attr>` and `<attr>_hash` attributes
+	secrets map[string]string
+	// parameter holding integration name, data sources can look up integrations by it
+	nameParam string
+	// additional parameters sent on every create and update
+	extraParams map[string]interface{}
+	// optional hooks for attributes that do not map to a single parameter
+	expand        func(d *schema.ResourceData, params map[string]interface{})
+	flatten       func(params map[string]interface{}, result map[string]interface{})
+	customizeDiff []schema.CustomizeDiffFunc
+	// optional hook for attributes that are not part of service instance parameters, called after every read
+	readExtra func(ctx context.Context, config *ProviderConfig, d *schema.ResourceData, svc *oc.GetServiceInstanceResponseServiceInstance, integrationID string, toolchainID string, envID string) error
+}
+
+func (r *serviceInstanceIntegration) resource(description string) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"toolchain_id": {
+			Description: "The toolchain `guid`",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"integration_id": {
+			Description: "The integration `guid`",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"env_id": {
+			Description: "Environment ID, example: `ibm:yp:us-south`",
+			Type:        schema.TypeString,
+			ForceNew:    true,
+			Required:    true,
+		},
+	}
+
+	for k, v := range r.schema {
+		s[k] = v
+	}
+
+	var customizeDiff []schema.CustomizeDiffFunc
+
+	for _, k := range r.secretAttrs() {
+		s["encrypted_"+k] = &schema.Schema{
+			Description: fmt.Sprintf("Since API only provides encrypted `%s` value, we can use that internally to detect changes made outside of Terraform", k),
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+		}
+
+		s[k+"_hash"] = &schema.Schema{
+			Description: fmt.Sprintf("Salted hash of `%s`, used internally to detect changes", k),
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+		}
+
+		customizeDiff = append(customizeDiff, customizeSecretHashDiff(k, k+"_hash"))
+	}
+
+	customizeDiff = append(customizeDiff, r.customizeDiff...)
+
+	resource := &schema.Resource{
+		Description:   description,
+		CreateContext: r.create,
+		ReadContext:   r.read,
+		DeleteContext: r.delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.All(customizeDiff...),
+		Schema:        s,
+	}
+
+	// SDK rejects resources with update function if none of the attributes can be updated
+	if r.updatable() {
+		resource.UpdateContext = r.update
+	}
+
+	return resource
+}
+
+// updatable returns true if any integration specific attribute can be changed without recreating the integration
+func (r *serviceInstanceIntegration) updatable() bool {
+	for _, v := range r.schema {
+		if !v.ForceNew && (v.Optional || v.Required) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dataSource returns data source with all non-sensitive resource attributes marked as computed
+func (r *serviceInstanceIntegration) dataSource(description string) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"toolchain_id": {
+			Description: "The toolchain `guid`",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"env_id": {
+			Description: "Environment ID, example: `ibm:yp:us-south`",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+	}
+
+	for k, v := range r.schema {
+		if v.Sensitive {
+			continue
+		}
+
+		s[k] = &schema.Schema{
+			Description: v.Description,
+			Type:        v.Type,
+			Elem:        computedElem(v.Elem),
+			Computed:    true,
+		}
+	}
+
+	setIntegrationLookupSchema(s, r.lookups())
+
+	return &schema.Resource{
+		Description: description,
+		ReadContext: r.dataSourceRead,
+		Schema:      s,
+	}
+}
+
+// computedElem converts nested resource attributes to computed
+func computedElem(elem interface{}) interface{} {
+	res, ok := elem.(*schema.Resource)
+
+	if !ok {
+		return elem
+	}
+
+	s := make(map[string]*schema.Schema)
+
+	for k, v := range res.Schema {
+		s[k] = &schema.Schema{
+			Description: v.Description,
+			Type:        v.Type,
+			Elem:        computedElem(v.Elem),
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{Schema: s}
+}
+
+// lookups returns data source lookup attributes, integrations can be found by the attribute mapped to `nameParam`
+func (r *serviceInstanceIntegration) lookups() []integrationLookup {
+	var result []integrationLookup
+
+	for k, v := range r.params {
+		if r.nameParam != "" && v == r.nameParam {
+			result = append(result, integrationLookup{attr: k, param: v})
+		}
+	}
+
+	return result
+}
+
+func (r *serviceInstanceIntegration) secretAttrs() []string {
+	result := make([]string, 0, len(r.secrets))
+
+	for k := range r.secrets {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func (r *serviceInstanceIntegration) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	config.ToolchainLocks.Lock(toolchainID)
+	defer config.ToolchainLocks.Unlock(toolchainID)
+
+	params := r.expandParams(d)
+
+	// parameters are sent as is, a failed follow-up patch would leave untracked integration behind
+	integrationID, err := createServiceInstanceWithID(ctx, c, toolchainID, envID, r.serviceType, params)
+
+	if err != nil {
+		return diag.Errorf("Error creating %s integration: %s", r.title, err)
+	}
+
+	for _, k := range r.secretAttrs() {
+		d.Set(k+"_hash", hashSecret(d.Get(k).(string)))
+	}
+
+	d.Set("integration_id", integrationID)
+	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
+
+	return r.read(ctx, d, m)
+}
+
+func (r *serviceInstanceIntegration) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Id()
+	idParts := strings.Split(id, "/")
+
+	if len(idParts) < 3 {
+		return diag.Errorf("Incorrect ID %s: ID should be a combination of integrationID/toolchainID/envID", d.Id())
+	}
+
+	integrationID := idParts[0]
+	toolchainID := idParts[1]
+	envID := idParts[2]
+
+	d.Set("integration_id", integrationID)
+	d.Set("toolchain_id", toolchainID)
+	d.Set("env_id", envID)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	svc, resp, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("[WARN] %s service instance '%s' is not found, removing it from state", r.title, integrationID)
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("Error reading %s service instance: %s", r.serviceType, err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		params := svc.ServiceInstance.Parameters
+
+		for k, v := range r.flattenParams(params) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting %s integration %s: %s", r.serviceType, k, err)
+			}
+		}
+
+		for _, k := range r.secretAttrs() {
+			if v, ok := params[r.secrets[k]].(string); ok {
+				readSecretDrift(d, "encrypted_"+k, k+"_hash", v)
+			}
+		}
+	}
+
+	if r.readExtra != nil {
+		if err := r.readExtra(ctx, config, d, svc.ServiceInstance, integrationID, toolchainID, envID); err != nil {
+			return diag.Errorf("Error reading %s integration: %s", r.title, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *serviceInstanceIntegration) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	instanceID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	var keys []string
+
+	for k := range r.schema {
+		if _, ok := r.secrets[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	changed := d.HasChanges(keys...)
+
+	for _, k := range r.secretAttrs() {
+		changed = changed || secretChanged(d, k, k+"_hash")
+	}
+
+	if changed {
+		err := patchServiceInstance(ctx, c, toolchainID, envID, r.serviceType, instanceID, r.expandParams(d))
+
+		if err != nil {
+			return diag.Errorf("Unable to update %s integration: %s", r.title, err)
+		}
+
+		// secrets are always sent, API re-encrypts them
+		for _, k := range r.secretAttrs() {
+			d.Set("encrypted_"+k, "")
+			d.Set(k+"_hash", hashSecret(d.Get(k).(string)))
+		}
+	}
+
+	return r.read(ctx, d, m)
+}
+
+func (r *serviceInstanceIntegration) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	integrationID := d.Get("integration_id").(string)
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	_, err := c.DeleteServiceInstanceWithContext(ctx, &oc.DeleteServiceInstanceOptions{
+		GUID:        &integrationID,
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error deleting %s integration: %s", r.title, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func (r *serviceInstanceIntegration) dataSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	envID := d.Get("env_id").(string)
+	toolchainID := d.Get("toolchain_id").(string)
+
+	config := m.(*ProviderConfig)
+	c := config.OTClient
+
+	integrationID, err := getDataSourceIntegrationID(ctx, c, d, r.serviceType, r.lookups())
+
+	if err != nil {
+		return diag.Errorf("Error looking up %s integration: %s", r.title, err)
+	}
+
+	d.Set("integration_id", integrationID)
+
+	svc, _, err := c.GetServiceInstanceWithContext(ctx, &oc.GetServiceInstanceOptions{
+		EnvID:       &envID,
+		ToolchainID: &toolchainID,
+		GUID:        &integrationID,
+	})
+
+	if err != nil {
+		return diag.Errorf("Error reading %s service instance: %s", r.serviceType, err)
+	}
+
+	if svc.ServiceInstance != nil && svc.ServiceInstance.Parameters != nil {
+		for k, v := range r.flattenParams(svc.ServiceInstance.Parameters) {
+			if err := d.Set(k, v); err != nil {
+				return diag.Errorf("Error setting %s integration %s: %s", r.serviceType, k, err)
+			}
+		}
+	}
+
+	if r.readExtra != nil {
+		if err := r.readExtra(ctx, config, d, svc.ServiceInstance, integrationID, toolchainID, envID); err != nil {
+			return diag.Errorf("Error reading %s integration: %s", r.title, err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", integrationID, toolchainID, envID))
+
+	return nil
+}
+
+func (r *serviceInstanceIntegration) expandParams(d *schema.ResourceData) map[string]interface{} {
+	params := make(map[string]interface{})
+
+	for k, v := range r.extraParams {
+		params[k] = v
+	}
+
+	for attr, param := range r.params {
+		params[param] = d.Get(attr)
+	}
+
+	for attr, param := range r.secrets {
+		if v, ok := d.GetOk(attr); ok {
+			params[param] = v.(string)
+		}
+	}
+
+	if r.expand != nil {
+		r.expand(d, params)
+	}
+
+	return params
+}
+
+// flattenParams maps service instance parameters to integration attributes, secrets are handled separately
+func (r *serviceInstanceIntegration) flattenParams(params map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	for attr, param := range r.params {
+		switch v := params[param].(type) {
+		case string, bool:
+			result[attr] = v
+		}
+	}
+
+	if r.flatten != nil {
+		r.flatten(params, result)
+	}
+
+	return result
+}
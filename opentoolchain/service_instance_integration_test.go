@@ -0,0 +1,120 @@
+package opentoolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestServiceInstanceIntegrationFlattenParams(t *testing.T) {
+	params := map[string]interface{}{
+		"name":             "sonarqube",
+		"dashboard_url":    "https://sonarqube.example.com",
+		"user_login":       "",
+		"user_password":    "encrypted",
+		"blind_connection": true,
+	}
+
+	expected := map[string]interface{}{
+		"name":             "sonarqube",
+		"server_url":       "https://sonarqube.example.com",
+		"user_login":       "",
+		"blind_connection": true,
+	}
+
+	assert.Equal(t, expected, sonarqubeIntegration.flattenParams(params))
+}
+
+func TestServiceInstanceIntegrationResourceSchema(t *testing.T) {
+	s := resourceOpenToolchainIntegrationSonarQube().Schema
+
+	assert.True(t, s["encrypted_token"].Computed)
+	assert.True(t, s["token_hash"].Sensitive)
+	assert.True(t, s["toolchain_id"].ForceNew)
+
+	s = dataSourceOpenToolchainIntegrationSonarQube().Schema
+
+	assert.True(t, s["blind_connection"].Computed)
+	assert.NotContains(t, s, "token")
+	assert.NotContains(t, s, "token_hash")
+}
+
+func TestServiceInstanceIntegrationExtraParams(t *testing.T) {
+	d := resourceOpenToolchainIntegrationJira().TestResourceData()
+	d.Set("server_url", "https://example.atlassian.net")
+	d.Set("project_key", "PRJ")
+	d.Set("user", "user@example.com")
+	d.Set("api_token", "token")
+
+	expected := map[string]interface{}{
+		"type":                "existing",
+		"api_url":             "https://example.atlassian.net",
+		"project_key":         "PRJ",
+		"username":            "user@example.com",
+		"password":            "token",
+		"enable_traceability": false,
+	}
+
+	assert.Equal(t, expected, jiraIntegration.expandParams(d))
+}
+
+func TestServiceInstanceIntegrationFlattenHook(t *testing.T) {
+	params := map[string]interface{}{
+		"instance_name":   "grafana",
+		"type":            "Grafana",
+		"dashboard_url":   "https://grafana.example.com",
+		"lifecycle_phase": "MANAGE",
+		"additional_properties": map[string]interface{}{
+			"team":  "sre",
+			"count": 1,
+		},
+	}
+
+	expected := map[string]interface{}{
+		"name":            "grafana",
+		"type":            "Grafana",
+		"url":             "https://grafana.example.com",
+		"lifecycle_phase": "MANAGE",
+		"additional_properties": map[string]interface{}{
+			"team": "sre",
+		},
+	}
+
+	assert.Equal(t, expected, customToolIntegration.flattenParams(params))
+}
+
+func TestCloudInstanceParams(t *testing.T) {
+	d := resourceOpenToolchainIntegrationCOS().TestResourceData()
+	d.Set("name", "cos")
+	d.Set("instance_crn", "crn:v1:bluemix:public:cloud-object-storage:global:a/xxxx:yyyy::")
+
+	assert.Equal(t, map[string]interface{}{
+		"name":             "cos",
+		"instance-id-type": "instance-crn",
+		"instance-crn":     "crn:v1:bluemix:public:cloud-object-storage:global:a/xxxx:yyyy::",
+	}, cosIntegration.expandParams(d))
+
+	d = resourceOpenToolchainIntegrationAppConfiguration().TestResourceData()
+	d.Set("name", "flags")
+	d.Set("instance_name", "app-config")
+	d.Set("instance_region", "ibm:yp:us-east")
+	d.Set("resource_group", "default")
+
+	params := appConfigurationIntegration.expandParams(d)
+
+	assert.Equal(t, map[string]interface{}{
+		"name":             "flags",
+		"instance-id-type": "instance-name",
+		"instance-name":    "app-config",
+		"region":           "ibm:yp:us-east",
+		"resource-group":   "default",
+	}, params)
+
+	params["instance-crn"] = ""
+
+	assert.Equal(t, map[string]interface{}{
+		"name":            "flags",
+		"instance_name":   "app-config",
+		"instance_region": "ibm:yp:us-east",
+		"resource_group":  "default",
+	}, appConfigurationIntegration.flattenParams(params))
+}